@@ -0,0 +1,230 @@
+package commercio
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/commercionetwork/sacco.go"
+	sdkErr "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// accountState holds the informations needed to sign transactions for an account, shared between all the
+// goroutines using the same SDK.
+type accountState struct {
+	mu sync.Mutex
+
+	// chainID is the ID of the chain transactions are signed for, empty if not fetched yet.
+	chainID string
+
+	// loaded is false when accountNumber and sequence must be fetched from the LCD before being used.
+	loaded        bool
+	accountNumber uint64
+	sequence      uint64
+
+	// stale is true when sequence must be fetched again from the LCD before being used, because a transaction
+	// has been rejected for a sequence mismatch.
+	stale bool
+
+	// pending holds the sequences of the transactions accepted by the LCD which might not have been committed
+	// yet, so that they are not reused when sequence gets synchronized with the chain.
+	pending map[uint64]struct{}
+}
+
+// maxPendingSequences is how many sequences behind the last one handed out are tracked as pending: older
+// transactions are assumed to have been either committed or dropped from the mempool.
+const maxPendingSequences = 1000
+
+// AccountSequence returns the account number and the sequence the SDK will use to sign the next transaction.
+// The returned values are meaningful only after the SDK sent its first transaction, or after a ResyncAccount or
+// SetAccountSequence call.
+func (sdk *SDK) AccountSequence() (uint64, uint64) {
+	sdk.account.mu.Lock()
+	defer sdk.account.mu.Unlock()
+
+	return sdk.account.accountNumber, sdk.account.sequence
+}
+
+// SetAccountSequence overrides the account number and the sequence the SDK will use to sign the next transaction.
+// Subsequent transactions will use increasing sequences starting from sequence, skipping the ones of the
+// transactions the SDK sent which might not have been committed yet.
+func (sdk *SDK) SetAccountSequence(accountNumber, sequence uint64) {
+	sdk.account.mu.Lock()
+	defer sdk.account.mu.Unlock()
+
+	sdk.account.accountNumber = accountNumber
+	sdk.account.sequence = sequence
+	sdk.account.loaded = true
+	sdk.account.stale = false
+}
+
+// ResyncAccount fetches chain ID, account number and sequence from the pre-defined LCD, discarding the ones
+// the SDK tracks locally, including the sequences of the transactions which might not have been committed yet.
+func (sdk *SDK) ResyncAccount() error {
+	sdk.account.mu.Lock()
+	defer sdk.account.mu.Unlock()
+
+	sdk.account.chainID = ""
+	sdk.account.loaded = false
+	sdk.account.stale = false
+	sdk.account.pending = nil
+
+	return sdk.loadAccount()
+}
+
+// loadAccount fetches from the pre-defined LCD the account informations that haven't been loaded yet.
+// It must be called with sdk.account.mu locked.
+func (sdk *SDK) loadAccount() error {
+	if sdk.account.chainID == "" {
+		chainID, err := sdk.lcdChainID()
+		if err != nil {
+			return err
+		}
+
+		if err := sdk.checkChainID(chainID); err != nil {
+			return err
+		}
+
+		sdk.account.chainID = chainID
+	}
+
+	if !sdk.account.loaded || sdk.account.stale {
+		ad, err := sdk.accountData(sdk.Address)
+		if err != nil {
+			return fmt.Errorf("could not get account informations for address %s: %w", sdk.Address, err)
+		}
+
+		// the LCD returns the sequence of the last committed block: restart from it, pending sequences lower than
+		// it have been committed
+		sdk.account.sequence = uint64(ad.Sequence)
+		for pending := range sdk.account.pending {
+			if pending < sdk.account.sequence {
+				delete(sdk.account.pending, pending)
+			}
+		}
+
+		sdk.account.accountNumber = uint64(ad.AccountNumber)
+		sdk.account.loaded = true
+		sdk.account.stale = false
+	}
+
+	return nil
+}
+
+// nextSequence returns chain ID, account number and sequence to be used to sign a transaction, and reserves the
+// sequence so that no other transaction will use it.
+func (sdk *SDK) nextSequence() (string, uint64, uint64, error) {
+	sdk.account.mu.Lock()
+	defer sdk.account.mu.Unlock()
+
+	if err := sdk.loadAccount(); err != nil {
+		return "", 0, 0, err
+	}
+
+	// transactions signed with pending sequences might still be waiting in the mempool: never reuse them
+	for {
+		if _, ok := sdk.account.pending[sdk.account.sequence]; !ok {
+			break
+		}
+
+		sdk.account.sequence++
+	}
+
+	sequence := sdk.account.sequence
+	sdk.account.sequence++
+
+	return sdk.account.chainID, sdk.account.accountNumber, sequence, nil
+}
+
+// staleAccount makes the SDK fetch its sequence from the LCD before signing the next transaction, skipping the
+// pending ones unless dropPending is true.
+func (sdk *SDK) staleAccount(dropPending bool) {
+	sdk.account.mu.Lock()
+	defer sdk.account.mu.Unlock()
+
+	sdk.account.stale = true
+
+	if dropPending {
+		sdk.account.pending = nil
+	}
+}
+
+// pendingSequence records that the transaction signed with sequence has been accepted by the LCD, and might be
+// waiting in the mempool.
+func (sdk *SDK) pendingSequence(sequence uint64) {
+	sdk.account.mu.Lock()
+	defer sdk.account.mu.Unlock()
+
+	if sdk.account.pending == nil {
+		sdk.account.pending = map[uint64]struct{}{}
+	}
+
+	sdk.account.pending[sequence] = struct{}{}
+
+	if len(sdk.account.pending) > maxPendingSequences {
+		for pending := range sdk.account.pending {
+			if pending+maxPendingSequences < sequence {
+				delete(sdk.account.pending, pending)
+			}
+		}
+	}
+}
+
+// releaseSequence gives sequence back, so that the next transaction uses it, if it's the last one handed out.
+// It must be called only for transactions which surely didn't reach the mempool.
+func (sdk *SDK) releaseSequence(sequence uint64) {
+	sdk.account.mu.Lock()
+	defer sdk.account.mu.Unlock()
+
+	if sdk.account.loaded && sdk.account.sequence == sequence+1 {
+		sdk.account.sequence = sequence
+	}
+}
+
+// isSequenceMismatch returns true if txr reports that the transaction signature couldn't be verified, which
+// usually happens when the transaction has been signed with a wrong sequence.
+func isSequenceMismatch(txr sacco.TxResponse) bool {
+	return txr.Codespace == sdkErr.RootCodespace &&
+		(txr.Code == sdkErr.ErrUnauthorized.ABCICode() || txr.Code == sdkErr.ErrInvalidSequence.ABCICode())
+}
+
+// signAndBroadcast signs txp with the next available sequence, then broadcasts it and returns the transaction hash.
+// If the transaction gets rejected because of a sequence mismatch, the sequence gets synchronized with the LCD and
+// the transaction is signed and broadcasted again, once.
+// Sequences of transactions accepted by the LCD are never reused, even if they haven't been committed yet.
+func (sdk *SDK) signAndBroadcast(txp sacco.TransactionPayload) (string, error) {
+	for attempt := 0; ; attempt++ {
+		chainID, accountNumber, sequence, err := sdk.nextSequence()
+		if err != nil {
+			return "", err
+		}
+
+		signed, err := sdk.SignTx(UnsignedTx(txp), accountNumber, sequence, chainID)
+		if err != nil {
+			return "", err
+		}
+
+		txr, err := sdk.broadcast(signed)
+		if err == nil {
+			sdk.pendingSequence(sequence)
+			return txr.TxHash, nil
+		}
+
+		if !isSequenceMismatch(txr) {
+			// a transaction rejected by the LCD didn't consume its sequence, while one which failed to reach it
+			// might have
+			if txr.Code != 0 {
+				sdk.releaseSequence(sequence)
+			}
+
+			return "", err
+		}
+
+		// a mismatch right after a resync means that some pending transaction has been dropped from the mempool:
+		// forget them, so that the next transaction starts from the chain sequence
+		sdk.staleAccount(attempt > 0)
+
+		if attempt > 0 {
+			return "", err
+		}
+	}
+}
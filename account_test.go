@@ -0,0 +1,275 @@
+package commercio
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+// registerAccountResponders registers the LCD responders needed to sign and broadcast transactions, with
+// txsResponder replying to broadcast requests.
+func registerAccountResponders(sdk *SDK, sequence int64, txsResponder httpmock.Responder) {
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/node_info", httpmock.NewStringResponder(http.StatusOK, `{"node_info":{"network":"commercio-testnet"}}`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/auth/accounts/"+sdk.Address, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.AccountData{Result: sacco.AccountDataResult{Value: sacco.AccountDataValue{
+		Address:       sdk.Address,
+		AccountNumber: 42,
+		Sequence:      sequence,
+	}}}))
+	httpmock.RegisterResponder(http.MethodPost, "http://localhost:1317/txs", txsResponder)
+}
+
+func TestSDK_SendTransaction_concurrentSequences(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// static responders share their body between calls, build a new response each time
+	registerAccountResponders(sdk, 10, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewJsonResponse(http.StatusOK, sacco.TxResponse{TxHash: "ok!"})
+	})
+
+	const txs = 100
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < txs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := sdk.SendTransaction(MsgSend{})
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	accountNumber, sequence := sdk.AccountSequence()
+	require.Equal(t, uint64(42), accountNumber)
+	require.Equal(t, uint64(10+txs), sequence)
+
+	// account data must have been fetched only once
+	calls := httpmock.GetCallCountInfo()
+	require.Equal(t, 1, calls["GET http://localhost:1317/auth/accounts/"+sdk.Address])
+	require.Equal(t, 1, calls["GET http://localhost:1317/node_info"])
+	require.Equal(t, txs, calls["POST http://localhost:1317/txs"])
+}
+
+func TestSDK_SendTransaction_sequenceMismatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		responses     []sacco.TxResponse
+		wantErr       bool
+		wantBroadcast int
+	}{
+		{
+			"sequence mismatch, then success",
+			[]sacco.TxResponse{
+				{Code: 4, Codespace: "sdk", RawLog: "signature verification failed; verify correct account sequence and chain-id"},
+				{TxHash: "ok!"},
+			},
+			false,
+			2,
+		},
+		{
+			"sequence mismatch twice",
+			[]sacco.TxResponse{
+				{Code: 4, Codespace: "sdk", RawLog: "signature verification failed; verify correct account sequence and chain-id"},
+				{Code: 4, Codespace: "sdk", RawLog: "signature verification failed; verify correct account sequence and chain-id"},
+			},
+			true,
+			2,
+		},
+		{
+			"other errors are not retried",
+			[]sacco.TxResponse{
+				{Code: 5, Codespace: "sdk", RawLog: "insufficient funds"},
+			},
+			true,
+			1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+			require.NoError(t, err)
+
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			broadcasts := 0
+			registerAccountResponders(sdk, 10, func(req *http.Request) (*http.Response, error) {
+				resp := tt.responses[broadcasts]
+				broadcasts++
+				return httpmock.NewJsonResponse(http.StatusOK, resp)
+			})
+
+			res, err := sdk.SendTransaction(MsgSend{})
+			require.Equal(t, tt.wantBroadcast, broadcasts)
+
+			// account data gets fetched again after each failure
+			calls := httpmock.GetCallCountInfo()
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, "", res)
+				require.Equal(t, tt.wantBroadcast, calls["GET http://localhost:1317/auth/accounts/"+sdk.Address])
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "ok!", res)
+			require.Equal(t, 2, calls["GET http://localhost:1317/auth/accounts/"+sdk.Address])
+		})
+	}
+}
+
+func TestSDK_SetAccountSequence(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerAccountResponders(sdk, 10, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "ok!"}))
+
+	sdk.SetAccountSequence(1, 100)
+
+	_, err = sdk.SendTransaction(MsgSend{})
+	require.NoError(t, err)
+
+	accountNumber, sequence := sdk.AccountSequence()
+	require.Equal(t, uint64(1), accountNumber)
+	require.Equal(t, uint64(101), sequence)
+
+	// manually set values must not be fetched from the LCD
+	require.Equal(t, 0, httpmock.GetCallCountInfo()["GET http://localhost:1317/auth/accounts/"+sdk.Address])
+
+	require.NoError(t, sdk.ResyncAccount())
+
+	accountNumber, sequence = sdk.AccountSequence()
+	require.Equal(t, uint64(42), accountNumber)
+	require.Equal(t, uint64(10), sequence)
+}
+
+func TestSDK_ResyncAccount(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/node_info", httpmock.NewJsonResponderOrPanic(http.StatusInternalServerError, sacco.Error{Error: "error!"}))
+	require.Error(t, sdk.ResyncAccount())
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/node_info", httpmock.NewStringResponder(http.StatusOK, `{"node_info":{"network":"commercio-testnet"}}`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/auth/accounts/"+sdk.Address, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.AccountData{}))
+	require.Error(t, sdk.ResyncAccount())
+}
+
+func TestSDK_SendTransaction_sequenceReuse(t *testing.T) {
+	tests := []struct {
+		name         string
+		responder    httpmock.Responder
+		wantSequence uint64
+	}{
+		{
+			"transport failure keeps the sequence reserved",
+			httpmock.NewErrorResponder(errors.New("connection reset")),
+			11,
+		},
+		{
+			"transaction rejected by the LCD gives the sequence back",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{Code: 5, Codespace: "sdk", RawLog: "insufficient funds"}),
+			10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+			require.NoError(t, err)
+
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			registerAccountResponders(sdk, 10, tt.responder)
+
+			_, err = sdk.SendTransaction(MsgSend{})
+			require.Error(t, err)
+
+			_, sequence := sdk.AccountSequence()
+			require.Equal(t, tt.wantSequence, sequence)
+
+			// failures other than sequence mismatches don't resync the account
+			_, err = sdk.SendTransaction(MsgSend{})
+			require.Error(t, err)
+			require.Equal(t, 1, httpmock.GetCallCountInfo()["GET http://localhost:1317/auth/accounts/"+sdk.Address])
+		})
+	}
+}
+
+func TestSDK_SendTransaction_sequenceMismatchResyncsToChain(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// the chain stays at sequence 10, while its mempool accepts only the sequence following the pending ones
+	expected := uint64(10)
+	var used []uint64
+	mempool := func(req *http.Request) (*http.Response, error) {
+		// the transaction has been signed with the sequence preceding the next one
+		_, next := sdk.AccountSequence()
+		sequence := next - 1
+
+		used = append(used, sequence)
+		if sequence != expected {
+			return httpmock.NewJsonResponse(http.StatusOK, sacco.TxResponse{Code: 4, Codespace: "sdk", RawLog: "signature verification failed"})
+		}
+
+		expected++
+		return httpmock.NewJsonResponse(http.StatusOK, sacco.TxResponse{TxHash: "ok!"})
+	}
+
+	// the first transaction never reaches the LCD, leaving the local sequence ahead of the chain
+	registerAccountResponders(sdk, 10, httpmock.NewErrorResponder(errors.New("connection reset")))
+
+	_, err = sdk.SendTransaction(MsgSend{})
+	require.Error(t, err)
+
+	httpmock.RegisterResponder(http.MethodPost, "http://localhost:1317/txs", mempool)
+
+	// the mismatch resyncs to the chain sequence, and the retry succeeds
+	_, err = sdk.SendTransaction(MsgSend{})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{11, 10}, used)
+
+	// after a mismatch the pending sequences 10 and 11 are skipped, even if the chain hasn't committed them yet
+	_, err = sdk.SendTransaction(MsgSend{})
+	require.NoError(t, err)
+
+	sdk.SetAccountSequence(42, 20)
+
+	used = nil
+	_, err = sdk.SendTransaction(MsgSend{})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{20, 12}, used)
+
+	_, sequence := sdk.AccountSequence()
+	require.Equal(t, uint64(13), sequence)
+
+	// pending transactions dropped from the mempool are forgotten after a second mismatch
+	expected = 10
+	used = nil
+	_, err = sdk.SendTransaction(MsgSend{})
+	require.Error(t, err)
+
+	_, err = sdk.SendTransaction(MsgSend{})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{13, 13, 10}, used)
+}
@@ -0,0 +1,147 @@
+package commercio
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/commercionetwork/commercionetwork/app"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/go-bip39"
+)
+
+// cosmosCoinType is the BIP44 coin type used by Cosmos-based chains.
+const cosmosCoinType = 118
+
+// accountKey identifies an account derived by an AccountManager.
+type accountKey struct {
+	account uint32
+	index   uint32
+}
+
+// AccountManager derives many accounts from a single mnemonic, along the m/44'/118'/account'/0/index BIP44
+// derivation path.
+// All the SDK instances returned by an AccountManager share the same codec and configuration.
+type AccountManager struct {
+	coinKey     *hdkeychain.ExtendedKey
+	config      SDKConfig
+	codec       *codec.Codec
+	typeMapping typeMapping
+
+	mu       sync.Mutex
+	accounts map[accountKey]*SDK
+}
+
+// NewAccountManager returns a new AccountManager deriving accounts from mnemonic, initialized by given config.
+// config.DerivationPath is not used, since each account has its own derivation path.
+// If config.VerifyChainID is true and the LCD is connected to another chain, the returned error matches both
+// ErrNewSDK and ErrChainIDMismatch.
+func NewAccountManager(mnemonic string, config SDKConfig) (*AccountManager, error) {
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, "invalid mnemonic")
+	}
+
+	config.hrp = hrp
+
+	master, err := hdkeychain.NewMaster(bip39.NewSeed(mnemonic, ""), &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	coinKey, err := deriveChildren(master, hdkeychain.HardenedKeyStart+44, hdkeychain.HardenedKeyStart+cosmosCoinType)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	appCodec := app.MakeCodec()
+
+	am := &AccountManager{
+		coinKey:     coinKey,
+		config:      config,
+		codec:       appCodec,
+		typeMapping: generateTypeMappings(appCodec),
+		accounts:    map[accountKey]*SDK{},
+	}
+
+	if config.VerifyChainID {
+		sdk, err := am.Account(0, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := sdk.verifyChainID(); err != nil {
+			return nil, wrap(ErrNewSDK, err)
+		}
+	}
+
+	return am, nil
+}
+
+// Account returns the SDK signing with the key derived along m/44'/118'/account'/0/index.
+// Subsequent calls with the same account and index return the same SDK, so that account sequences are tracked
+// across calls.
+func (am *AccountManager) Account(account, index uint32) (*SDK, error) {
+	if account >= hdkeychain.HardenedKeyStart || index >= hdkeychain.HardenedKeyStart {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, errors.New("account and index must be lower than 2^31"))
+	}
+
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	key := accountKey{account: account, index: index}
+	if sdk, ok := am.accounts[key]; ok {
+		return sdk, nil
+	}
+
+	kc, err := deriveChildren(am.coinKey, hdkeychain.HardenedKeyStart+account, 0, index)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	ec, err := kc.ECPrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	config := am.config
+	config.DerivationPath = fmt.Sprintf("m/44'/%d'/%d'/0/%d", cosmosCoinType, account, index)
+
+	sdk, err := newSDK(newPrivateKeySigner(ec), config, am.codec, am.typeMapping)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	am.accounts[key] = sdk
+
+	return sdk, nil
+}
+
+// SendTransaction sends all the messages contained in rawMsgs through the pre-defined LCD signing them with the
+// account derived along m/44'/118'/account'/0/index, then returns the transaction hash.
+func (am *AccountManager) SendTransaction(account, index uint32, rawMsgs ...interface{}) (string, error) {
+	sdk, err := am.Account(account, index)
+	if err != nil {
+		return "", err
+	}
+
+	return sdk.SendTransaction(rawMsgs...)
+}
+
+// deriveChildren derives from key the children identified by indexes, in order.
+func deriveChildren(key *hdkeychain.ExtendedKey, indexes ...uint32) (*hdkeychain.ExtendedKey, error) {
+	var err error
+	for _, i := range indexes {
+		key, err = key.Child(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
@@ -0,0 +1,169 @@
+package commercio
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAccountManager(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		config   SDKConfig
+		wantErr  bool
+	}{
+		{
+			"invalid config",
+			"first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus",
+			SDKConfig{},
+			true,
+		},
+		{
+			"invalid mnemonic",
+			"mnemonic",
+			DefaultSDKConfig,
+			true,
+		},
+		{
+			"well-formed mnemonic and config",
+			"first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus",
+			DefaultSDKConfig,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := NewAccountManager(tt.mnemonic, tt.config)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, res)
+		})
+	}
+}
+
+func TestNewAccountManager_verifyChainID(t *testing.T) {
+	config := DefaultSDKConfig
+	config.ChainID = "commercio-testnet"
+	config.VerifyChainID = true
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/node_info", httpmock.NewStringResponder(http.StatusOK, `{"node_info":{"network":"commercio-mainnet"}}`))
+
+	_, err := NewAccountManager("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", config)
+	require.True(t, errors.Is(err, ErrNewSDK))
+	require.True(t, errors.Is(err, ErrChainIDMismatch))
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/node_info", httpmock.NewStringResponder(http.StatusOK, `{"node_info":{"network":"commercio-testnet"}}`))
+
+	am, err := NewAccountManager("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", config)
+	require.NoError(t, err)
+	require.NotNil(t, am)
+}
+
+func TestAccountManager_Account(t *testing.T) {
+	mnemonic := "first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus"
+
+	am, err := NewAccountManager(mnemonic, DefaultSDKConfig)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		account        uint32
+		index          uint32
+		derivationPath string
+		wantErr        bool
+	}{
+		{
+			"default account",
+			0,
+			0,
+			sacco.CosmosDerivationPath,
+			false,
+		},
+		{
+			"other address index",
+			0,
+			3,
+			"m/44'/118'/0'/0/3",
+			false,
+		},
+		{
+			"other account",
+			2,
+			1,
+			"m/44'/118'/2'/0/1",
+			false,
+		},
+		{
+			"hardened account",
+			1 << 31,
+			0,
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := am.Account(tt.account, tt.index)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+
+			config := DefaultSDKConfig
+			config.DerivationPath = tt.derivationPath
+
+			expected, err := NewSDK(mnemonic, config)
+			require.NoError(t, err)
+			require.Equal(t, expected.Address, res.Address)
+			require.Equal(t, expected.PublicKey, res.PublicKey)
+			require.Equal(t, tt.derivationPath, res.config.DerivationPath)
+
+			// the same instance gets returned, sharing the codec with the other accounts
+			again, err := am.Account(tt.account, tt.index)
+			require.NoError(t, err)
+			require.Same(t, res, again)
+			require.Same(t, am.codec, res.codec)
+		})
+	}
+}
+
+func TestAccountManager_SendTransaction(t *testing.T) {
+	am, err := NewAccountManager("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	sdk, err := am.Account(1, 0)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerAccountResponders(sdk, 10, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "ok!"}))
+
+	res, err := am.SendTransaction(1, 0, MsgSend{})
+	require.NoError(t, err)
+	require.Equal(t, "ok!", res)
+
+	_, sequence := sdk.AccountSequence()
+	require.Equal(t, uint64(11), sequence)
+
+	_, err = am.SendTransaction(1<<31, 0, MsgSend{})
+	require.Error(t, err)
+}
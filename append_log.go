@@ -0,0 +1,98 @@
+package commercio
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// appendLog is a file persisting records one per line, each synced to disk as soon as it gets appended.
+// It's not safe for concurrent use: its users serialize the calls to its methods.
+type appendLog struct {
+	// name describes the file in error messages, e.g. "cursor file".
+	name string
+	path string
+	file *os.File
+}
+
+// openAppendLog opens the append log at path, creating it if it doesn't exist, and calls load with each non-empty
+// line already stored there.
+// If load returns an error, the log is closed and the error is returned.
+func openAppendLog(path, name string, load func(line string) error) (*appendLog, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not open %s: %w", name, err)
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if err := load(line); err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("could not read %s: %w", name, err)
+	}
+
+	return &appendLog{
+		name: name,
+		path: path,
+		file: file,
+	}, nil
+}
+
+// append writes line at the end of the log, and syncs it to disk.
+func (l *appendLog) append(line string) error {
+	if _, err := l.file.WriteString(line + "\n"); err != nil {
+		return fmt.Errorf("could not write %s: %w", l.name, err)
+	}
+
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("could not write %s: %w", l.name, err)
+	}
+
+	return nil
+}
+
+// rewrite atomically replaces the content of the log with lines.
+func (l *appendLog) rewrite(lines []string) error {
+	var data strings.Builder
+	for _, line := range lines {
+		data.WriteString(line + "\n")
+	}
+
+	tmp := filepath.Join(filepath.Dir(l.path), "."+filepath.Base(l.path)+".tmp")
+	if err := ioutil.WriteFile(tmp, []byte(data.String()), 0600); err != nil {
+		return fmt.Errorf("could not rewrite %s: %w", l.name, err)
+	}
+
+	if err := os.Rename(tmp, l.path); err != nil {
+		return fmt.Errorf("could not rewrite %s: %w", l.name, err)
+	}
+
+	file, err := os.OpenFile(l.path, os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("could not open %s: %w", l.name, err)
+	}
+
+	_ = l.file.Close()
+	l.file = file
+
+	return nil
+}
+
+// close closes the file backing the log.
+func (l *appendLog) close() error {
+	return l.file.Close()
+}
@@ -0,0 +1,60 @@
+package commercio
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "commercio-sdk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	noop := func(string) error { return nil }
+
+	_, err = openAppendLog(filepath.Join(dir, "missing", "log"), "log", noop)
+	require.Error(t, err)
+
+	path := filepath.Join(dir, "log")
+
+	log, err := openAppendLog(path, "log", noop)
+	require.NoError(t, err)
+
+	require.NoError(t, log.append("first"))
+	require.NoError(t, log.append("second"))
+	require.NoError(t, log.close())
+
+	// blank lines are skipped
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString("  \n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	var lines []string
+	log, err = openAppendLog(path, "log", func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second"}, lines)
+
+	// the log can still be appended to once rewritten
+	require.NoError(t, log.rewrite([]string{"third"}))
+	require.NoError(t, log.append("fourth"))
+	require.NoError(t, log.close())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "third\nfourth\n", string(data))
+
+	// load errors are returned as they are
+	loadErr := errors.New("malformed")
+	_, err = openAppendLog(path, "log", func(string) error { return loadErr })
+	require.Equal(t, loadErr, err)
+}
@@ -0,0 +1,83 @@
+package commercio
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+)
+
+// Checksum algorithms accepted by commercio.network.
+const (
+	ChecksumMD5    = "md5"
+	ChecksumSHA1   = "sha-1"
+	ChecksumSHA224 = "sha-224"
+	ChecksumSHA256 = "sha-256"
+	ChecksumSHA384 = "sha-384"
+	ChecksumSHA512 = "sha-512"
+)
+
+// checksumHashes associates each checksum algorithm to its hash constructor.
+var checksumHashes = map[string]func() hash.Hash{
+	ChecksumMD5:    md5.New,
+	ChecksumSHA1:   sha1.New,
+	ChecksumSHA224: sha256.New224,
+	ChecksumSHA256: sha256.New,
+	ChecksumSHA384: sha512.New384,
+	ChecksumSHA512: sha512.New,
+}
+
+// NewDocumentChecksum returns the DocumentChecksum of the content read from r, computed with algorithm.
+// r is read in a streaming fashion, so that content of any size can be hashed.
+func NewDocumentChecksum(r io.Reader, algorithm string) (DocumentChecksum, error) {
+	algorithm = strings.ToLower(algorithm)
+
+	value, err := checksumValue(r, algorithm)
+	if err != nil {
+		return DocumentChecksum{}, err
+	}
+
+	return DocumentChecksum{
+		Value:     value,
+		Algorithm: algorithm,
+	}, nil
+}
+
+// VerifyDocumentChecksum hashes the content read from r with the algorithm of the doc checksum, and returns
+// ErrChecksumMismatch if the result differs from the doc checksum value.
+func VerifyDocumentChecksum(doc Document, r io.Reader) error {
+	if doc.Checksum == nil {
+		return fmt.Errorf("%w, %s", ErrInvalidChecksum, "document has no checksum")
+	}
+
+	value, err := checksumValue(r, strings.ToLower(doc.Checksum.Algorithm))
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(value, doc.Checksum.Value) {
+		return fmt.Errorf("%w, expected %s but got %s", ErrChecksumMismatch, doc.Checksum.Value, value)
+	}
+
+	return nil
+}
+
+// checksumValue returns the hex-encoded hash of the content read from r, computed with algorithm.
+func checksumValue(r io.Reader, algorithm string) (string, error) {
+	newHash, ok := checksumHashes[algorithm]
+	if !ok {
+		return "", fmt.Errorf("%w, unsupported algorithm %s", ErrInvalidChecksum, algorithm)
+	}
+
+	h := newHash()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", fmt.Errorf("%w, %s", ErrInvalidChecksum, err.Error())
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
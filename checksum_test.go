@@ -0,0 +1,125 @@
+package commercio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/commercionetwork/commercionetwork/x/docs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDocumentChecksum(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		want      string
+		wantErr   bool
+	}{
+		{
+			"unsupported algorithm",
+			"sha-3",
+			"",
+			true,
+		},
+		{
+			"md5",
+			ChecksumMD5,
+			"900150983cd24fb0d6963f7d28e17f72",
+			false,
+		},
+		{
+			"sha-1",
+			ChecksumSHA1,
+			"a9993e364706816aba3e25717850c26c9cd0d89d",
+			false,
+		},
+		{
+			"sha-224",
+			ChecksumSHA224,
+			"23097d223405d8228642a477bda255b32aadbce4bda0b3f7e36c9da7",
+			false,
+		},
+		{
+			"sha-256",
+			ChecksumSHA256,
+			"ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+			false,
+		},
+		{
+			"sha-384",
+			ChecksumSHA384,
+			"cb00753f45a35e8bb5a03d699ac65007272c32ab0eded1631a8b605a43ff5bed8086072ba1e7cc2358baeca134c825a7",
+			false,
+		},
+		{
+			"sha-512, upper case",
+			"SHA-512",
+			"ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := NewDocumentChecksum(strings.NewReader("abc"), tt.algorithm)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, DocumentChecksum{}, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, res.Value)
+			require.NoError(t, docs.DocumentChecksum(res).Validate())
+		})
+	}
+
+	_, err := NewDocumentChecksum(failingReader{}, ChecksumSHA256)
+	require.True(t, errors.Is(err, ErrInvalidChecksum))
+}
+
+func TestVerifyDocumentChecksum(t *testing.T) {
+	checksum := docs.DocumentChecksum{
+		Value:     "BA7816BF8F01CFEA414140DE5DAE2223B00361A396177A9CB410FF61F20015AD",
+		Algorithm: "sha-256",
+	}
+
+	tests := []struct {
+		name    string
+		doc     Document
+		content string
+		wantErr error
+	}{
+		{
+			"document without checksum",
+			Document{},
+			"abc",
+			ErrInvalidChecksum,
+		},
+		{
+			"content doesn't match",
+			Document{Checksum: &checksum},
+			"abd",
+			ErrChecksumMismatch,
+		},
+		{
+			"content matches",
+			Document{Checksum: &checksum},
+			"abc",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyDocumentChecksum(tt.doc, strings.NewReader(tt.content))
+
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
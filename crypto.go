@@ -2,11 +2,15 @@ package commercio
 
 import (
 	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/cosmos/go-bip39"
 )
@@ -17,6 +21,9 @@ var entropyProvider = rand.Read
 // rsaGenKeyProvider by default uses rsa.GenerateKey, it has been defined for ease of testing.
 var rsaGenKeyProvider = rsa.GenerateKey
 
+// timeProvider by default uses time.Now, it has been defined for ease of testing.
+var timeProvider = time.Now
+
 // NewMnemonic return a cryptographically-secure wallet mnemonic.
 func NewMnemonic() (string, error) {
 	e := func(w error, ext error) (string, error) {
@@ -39,7 +46,16 @@ func NewMnemonic() (string, error) {
 
 // NewRSAKeypair returns a new PEM-encoded RSA 2048-bit keypair.
 func NewRSAKeypair() (string, string, error) {
-	pk, err := rsaGenKeyProvider(rand.Reader, 2048)
+	return NewRSAKeypairWithBits(DefaultCryptoProfile.RSAKeyBits)
+}
+
+// NewRSAKeypairWithBits returns a new PEM-encoded RSA keypair of the given size, either 2048, 3072 or 4096 bits.
+func NewRSAKeypairWithBits(bits int) (string, string, error) {
+	if err := validateRSAKeyBits(bits); err != nil {
+		return "", "", err
+	}
+
+	pk, err := rsaGenKeyProvider(rand.Reader, bits)
 	if err != nil {
 		return "", "", err
 	}
@@ -80,3 +96,61 @@ func NewRSAKeypair() (string, string, error) {
 
 	return pkStr.String(), pubkStr.String(), nil
 }
+
+// newAESKey returns a new random AES-256 key.
+func newAESKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("%w, cannot generate AES encryption key, %s", ErrNotEnoughEntropy, err.Error())
+	}
+
+	return key, nil
+}
+
+// aesEncrypt encrypts plaintext with AES-GCM and key, and returns the random nonce followed by the ciphertext.
+func aesEncrypt(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrEncryptionFailure, err.Error())
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrEncryptionFailure, err.Error())
+	}
+
+	nonce := make([]byte, aesgcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("%w, cannot generate AES nonce, %s", ErrNotEnoughEntropy, err.Error())
+	}
+
+	finalc := bytes.Buffer{}
+	finalc.Write(nonce)
+	finalc.Write(aesgcm.Seal(nil, nonce, plaintext, nil))
+
+	return finalc.Bytes(), nil
+}
+
+// aesDecrypt decrypts data, made of a nonce followed by an AES-GCM ciphertext, with key.
+func aesDecrypt(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrDecryptionFailure, err.Error())
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrDecryptionFailure, err.Error())
+	}
+
+	if len(data) < aesgcm.NonceSize() {
+		return nil, fmt.Errorf("%w, ciphertext too short", ErrTamperedCiphertext)
+	}
+
+	plaintext, err := aesgcm.Open(nil, data[:aesgcm.NonceSize()], data[aesgcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrTamperedCiphertext, err.Error())
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,151 @@
+package commercio
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+)
+
+// RSASignatureScheme represents the scheme used by the SDK to create and verify RSA signatures.
+type RSASignatureScheme string
+
+const (
+	// RSASignaturePKCS1v15 represents RSASSA-PKCS1-v1_5 signatures over SHA-256 digests.
+	RSASignaturePKCS1v15 RSASignatureScheme = "pkcs1v15"
+
+	// RSASignaturePSS represents RSASSA-PSS signatures over SHA-256 digests, with a salt as long as the digest.
+	RSASignaturePSS RSASignatureScheme = "pss"
+)
+
+// RSAEncryptionScheme represents the scheme used by the SDK to encrypt AES keys with RSA keys.
+type RSAEncryptionScheme string
+
+const (
+	// RSAEncryptionPKCS1v15 represents RSAES-PKCS1-v1_5 encryption.
+	RSAEncryptionPKCS1v15 RSAEncryptionScheme = "pkcs1v15"
+
+	// RSAEncryptionOAEP represents RSAES-OAEP encryption with SHA-256 and no label.
+	RSAEncryptionOAEP RSAEncryptionScheme = "oaep"
+)
+
+var (
+	// DefaultCryptoProfile is the CryptoProfile used when none is configured, compatible with the other
+	// commercio.network clients.
+	DefaultCryptoProfile = CryptoProfile{
+		RSAKeyBits:    2048,
+		Signature:     RSASignaturePKCS1v15,
+		KeyEncryption: RSAEncryptionPKCS1v15,
+	}
+)
+
+// CryptoProfile selects the RSA key size and schemes used to create identities, power-up requests and encrypted
+// documents.
+// Parties exchanging RSA-encrypted or RSA-signed data must use the same profile.
+type CryptoProfile struct {
+	// RSAKeyBits is the size of the RSA keys generated by the SDK, either 2048, 3072 or 4096.
+	RSAKeyBits int
+
+	// Signature is the scheme used to sign power-up request proofs.
+	Signature RSASignatureScheme
+
+	// KeyEncryption is the scheme used to encrypt the AES keys of power-up request proofs and encrypted documents.
+	KeyEncryption RSAEncryptionScheme
+}
+
+// withDefaults returns cp with its empty fields set as in DefaultCryptoProfile.
+func (cp CryptoProfile) withDefaults() CryptoProfile {
+	if cp.RSAKeyBits == 0 {
+		cp.RSAKeyBits = DefaultCryptoProfile.RSAKeyBits
+	}
+
+	if cp.Signature == "" {
+		cp.Signature = DefaultCryptoProfile.Signature
+	}
+
+	if cp.KeyEncryption == "" {
+		cp.KeyEncryption = DefaultCryptoProfile.KeyEncryption
+	}
+
+	return cp
+}
+
+// validate checks that cp only contains supported values, empty fields are considered valid.
+func (cp CryptoProfile) validate() error {
+	cp = cp.withDefaults()
+
+	if err := validateRSAKeyBits(cp.RSAKeyBits); err != nil {
+		return err
+	}
+
+	if cp.Signature != RSASignaturePKCS1v15 && cp.Signature != RSASignaturePSS {
+		return fmt.Errorf("unsupported RSA signature scheme %s", cp.Signature)
+	}
+
+	if cp.KeyEncryption != RSAEncryptionPKCS1v15 && cp.KeyEncryption != RSAEncryptionOAEP {
+		return fmt.Errorf("unsupported RSA encryption scheme %s", cp.KeyEncryption)
+	}
+
+	return nil
+}
+
+// sign signs the SHA-256 digest of data with key.
+func (cp CryptoProfile) sign(key *rsa.PrivateKey, data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+
+	switch cp.withDefaults().Signature {
+	case RSASignaturePSS:
+		return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], pssOptions)
+	default:
+		return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	}
+}
+
+// verify checks that signature has been created by signing the SHA-256 digest of data with the private part of key.
+func (cp CryptoProfile) verify(key *rsa.PublicKey, data, signature []byte) error {
+	digest := sha256.Sum256(data)
+
+	switch cp.withDefaults().Signature {
+	case RSASignaturePSS:
+		return rsa.VerifyPSS(key, crypto.SHA256, digest[:], signature, pssOptions)
+	default:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+	}
+}
+
+// encrypt encrypts plaintext with key.
+func (cp CryptoProfile) encrypt(key *rsa.PublicKey, plaintext []byte) ([]byte, error) {
+	switch cp.withDefaults().KeyEncryption {
+	case RSAEncryptionOAEP:
+		return rsa.EncryptOAEP(sha256.New(), rand.Reader, key, plaintext, nil)
+	default:
+		return rsa.EncryptPKCS1v15(rand.Reader, key, plaintext)
+	}
+}
+
+// decrypt decrypts ciphertext with key.
+func (cp CryptoProfile) decrypt(key *rsa.PrivateKey, ciphertext []byte) ([]byte, error) {
+	switch cp.withDefaults().KeyEncryption {
+	case RSAEncryptionOAEP:
+		return rsa.DecryptOAEP(sha256.New(), rand.Reader, key, ciphertext, nil)
+	default:
+		return rsa.DecryptPKCS1v15(rand.Reader, key, ciphertext)
+	}
+}
+
+// pssOptions are the options used to create and verify RSASSA-PSS signatures.
+var pssOptions = &rsa.PSSOptions{
+	SaltLength: rsa.PSSSaltLengthEqualsHash,
+	Hash:       crypto.SHA256,
+}
+
+// validateRSAKeyBits checks that bits is a supported RSA key size.
+func validateRSAKeyBits(bits int) error {
+	switch bits {
+	case 2048, 3072, 4096:
+		return nil
+	default:
+		return fmt.Errorf("unsupported RSA key size %d, must be 2048, 3072 or 4096", bits)
+	}
+}
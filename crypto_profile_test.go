@@ -0,0 +1,199 @@
+package commercio
+
+import (
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/commercionetwork/commercionetwork/x/docs"
+	"github.com/commercionetwork/sacco.go"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// testRSAKeys returns the keys of a new RSA keypair, parsed.
+func testRSAKeys(t *testing.T) (*rsa.PrivateKey, *rsa.PublicKey) {
+	privateKey, _ := testRSAKeypair(t)
+
+	_, rawKey, err := readKey(strings.NewReader(privateKey), typePrivateKey)
+	require.NoError(t, err)
+
+	key := rawKey.(*rsa.PrivateKey)
+	return key, &key.PublicKey
+}
+
+func TestCryptoProfile_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile CryptoProfile
+		wantErr bool
+	}{
+		{"empty profile", CryptoProfile{}, false},
+		{"default profile", DefaultCryptoProfile, false},
+		{"pss, oaep and 4096-bit keys", CryptoProfile{RSAKeyBits: 4096, Signature: RSASignaturePSS, KeyEncryption: RSAEncryptionOAEP}, false},
+		{"unsupported key size", CryptoProfile{RSAKeyBits: 1024}, true},
+		{"unsupported signature scheme", CryptoProfile{Signature: "rsa"}, true},
+		{"unsupported encryption scheme", CryptoProfile{KeyEncryption: "rsa"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr {
+				require.Error(t, tt.profile.validate())
+
+				config := DefaultSDKConfig
+				config.CryptoProfile = tt.profile
+				require.Error(t, config.validate())
+				return
+			}
+
+			require.NoError(t, tt.profile.validate())
+		})
+	}
+}
+
+func TestCryptoProfile_schemes(t *testing.T) {
+	privateKey, publicKey := testRSAKeys(t)
+
+	profiles := map[string]CryptoProfile{
+		"default":   {},
+		"pkcs1v15":  {Signature: RSASignaturePKCS1v15, KeyEncryption: RSAEncryptionPKCS1v15},
+		"pss, oaep": {Signature: RSASignaturePSS, KeyEncryption: RSAEncryptionOAEP},
+	}
+	for name, profile := range profiles {
+		t.Run(name, func(t *testing.T) {
+			signature, err := profile.sign(privateKey, []byte("data"))
+			require.NoError(t, err)
+			require.NoError(t, profile.verify(publicKey, []byte("data"), signature))
+			require.Error(t, profile.verify(publicKey, []byte("other data"), signature))
+
+			ciphertext, err := profile.encrypt(publicKey, []byte("key"))
+			require.NoError(t, err)
+
+			plaintext, err := profile.decrypt(privateKey, ciphertext)
+			require.NoError(t, err)
+			require.Equal(t, "key", string(plaintext))
+		})
+	}
+
+	// schemes are not interchangeable
+	pkcs, pss := profiles["pkcs1v15"], profiles["pss, oaep"]
+
+	signature, err := pss.sign(privateKey, []byte("data"))
+	require.NoError(t, err)
+	require.Error(t, pkcs.verify(publicKey, []byte("data"), signature))
+
+	ciphertext, err := pss.encrypt(publicKey, []byte("key"))
+	require.NoError(t, err)
+	_, err = pkcs.decrypt(privateKey, ciphertext)
+	require.Error(t, err)
+}
+
+func TestNewRSAKeypairWithBits(t *testing.T) {
+	privateKey, publicKey, err := NewRSAKeypairWithBits(3072)
+	require.NoError(t, err)
+
+	_, rawKey, err := readKey(strings.NewReader(publicKey), typePublicKey)
+	require.NoError(t, err)
+	require.Equal(t, 3072, rawKey.(*rsa.PublicKey).N.BitLen())
+
+	_, _, err = readKey(strings.NewReader(privateKey), typePrivateKey)
+	require.NoError(t, err)
+
+	privateKey, publicKey, err = NewRSAKeypairWithBits(1024)
+	require.Error(t, err)
+	require.Empty(t, privateKey)
+	require.Empty(t, publicKey)
+}
+
+func TestCryptoProfile_powerupRoundTrip(t *testing.T) {
+	config := DefaultSDKConfig
+	config.CryptoProfile = CryptoProfile{Signature: RSASignaturePSS, KeyEncryption: RSAEncryptionOAEP}
+
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", config)
+	require.NoError(t, err)
+
+	tumblerPrivateKey, tumblerPublicKey := testRSAKeypair(t)
+	signaturePrivateKey, signaturePublicKey := testRSAKeypair(t)
+
+	msg, err := sdk.BuildPowerupRequest(PowerUpParams{
+		PubKey:          sdk.PublicKey,
+		TumblerKey:      strings.NewReader(tumblerPublicKey),
+		SignatureKey:    strings.NewReader(signaturePrivateKey),
+		Amount:          42,
+		PairwiseAddress: types.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+	})
+	require.NoError(t, err)
+
+	_, err = OpenPowerupProofWithOptions(msg, strings.NewReader(tumblerPrivateKey), strings.NewReader(signaturePublicKey), PowerupProofOptions{CryptoProfile: config.CryptoProfile})
+	require.NoError(t, err)
+
+	// the default profile can't open it
+	_, err = OpenPowerupProof(msg, strings.NewReader(tumblerPrivateKey), strings.NewReader(signaturePublicKey))
+	require.True(t, errors.Is(err, ErrDecryptionFailure))
+
+	_, err = OpenPowerupProofWithOptions(msg, strings.NewReader(tumblerPrivateKey), strings.NewReader(signaturePublicKey), PowerupProofOptions{CryptoProfile: CryptoProfile{Signature: "rsa"}})
+	require.True(t, errors.Is(err, ErrInvalidProofOptions))
+}
+
+func TestCryptoProfile_documentRoundTrip(t *testing.T) {
+	config := DefaultSDKConfig
+	config.CryptoProfile = CryptoProfile{KeyEncryption: RSAEncryptionOAEP}
+
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", config)
+	require.NoError(t, err)
+
+	defaultSDK, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	self, err := Address(sdk.Address)
+	require.NoError(t, err)
+
+	privateKey, publicKey := testRSAKeypair(t)
+
+	msg, err := sdk.BuildEncryptedShareDocument(ShareDocumentParams{
+		Metadata: DocumentMetadata{
+			ContentURI: "https://example.com/metadata",
+			Schema: &docs.DocumentMetadataSchema{
+				URI:     "https://example.com/schema",
+				Version: "1.0.0",
+			},
+		},
+		ContentURI:      "https://example.com/document",
+		Recipients:      []DocumentRecipient{{Address: self, EncryptionKey: strings.NewReader(publicKey)}},
+		EncryptedFields: []string{EncryptedFieldContentURI},
+	})
+	require.NoError(t, err)
+
+	doc, err := sdk.DecryptDocument(Document(msg), strings.NewReader(privateKey))
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/document", doc.ContentURI)
+
+	_, err = defaultSDK.DecryptDocument(Document(msg), strings.NewReader(privateKey))
+	require.Error(t, err)
+}
+
+func TestSDK_CreateIdentity_cryptoProfile(t *testing.T) {
+	config := DefaultSDKConfig
+	config.CryptoProfile = CryptoProfile{RSAKeyBits: 3072}
+
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", config)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerAccountResponders(sdk, 0, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "ok!"}))
+
+	res, err := sdk.CreateIdentity(IdentityOptions{})
+	require.NoError(t, err)
+
+	for _, key := range []string{res.Keys.VerificationPublicKey, res.Keys.SignaturePublicKey} {
+		_, rawKey, err := readKey(strings.NewReader(key), typePublicKey)
+		require.NoError(t, err)
+		require.Equal(t, 3072, rawKey.(*rsa.PublicKey).N.BitLen())
+	}
+}
@@ -0,0 +1,104 @@
+package commercio
+
+import (
+	"sync"
+)
+
+// CursorStore keeps track of the items a DocumentWatcher already delivered, so that each item is delivered only
+// once, even across restarts if the store is persistent.
+// Implementations must be safe for concurrent use.
+type CursorStore interface {
+	// Seen returns true if the item identified by key has already been delivered.
+	Seen(key string) (bool, error)
+
+	// MarkSeen records that the item identified by key has been delivered.
+	MarkSeen(key string) error
+}
+
+// MemoryCursorStore is a CursorStore keeping delivered items in memory.
+type MemoryCursorStore struct {
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+// NewMemoryCursorStore returns an empty MemoryCursorStore.
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{
+		seen: map[string]struct{}{},
+	}
+}
+
+// Seen implements CursorStore.
+func (m *MemoryCursorStore) Seen(key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.seen[key]
+	return ok, nil
+}
+
+// MarkSeen implements CursorStore.
+func (m *MemoryCursorStore) MarkSeen(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.seen[key] = struct{}{}
+	return nil
+}
+
+// FileCursorStore is a CursorStore persisting delivered items in a file, one key per line.
+type FileCursorStore struct {
+	memory *MemoryCursorStore
+
+	mu  sync.Mutex
+	log *appendLog
+}
+
+// NewFileCursorStore returns a FileCursorStore persisting delivered items in the file at path, loading the ones
+// already stored there.
+// The file is created if it doesn't exist.
+func NewFileCursorStore(path string) (*FileCursorStore, error) {
+	memory := NewMemoryCursorStore()
+
+	log, err := openAppendLog(path, "cursor file", func(key string) error {
+		memory.seen[key] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileCursorStore{
+		memory: memory,
+		log:    log,
+	}, nil
+}
+
+// Seen implements CursorStore.
+func (f *FileCursorStore) Seen(key string) (bool, error) {
+	return f.memory.Seen(key)
+}
+
+// MarkSeen implements CursorStore.
+func (f *FileCursorStore) MarkSeen(key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if seen, _ := f.memory.Seen(key); seen {
+		return nil
+	}
+
+	if err := f.log.append(key); err != nil {
+		return err
+	}
+
+	return f.memory.MarkSeen(key)
+}
+
+// Close closes the file backing f.
+func (f *FileCursorStore) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.log.close()
+}
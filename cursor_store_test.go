@@ -0,0 +1,58 @@
+package commercio
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCursorStore(t *testing.T) {
+	store := NewMemoryCursorStore()
+
+	seen, err := store.Seen("key")
+	require.NoError(t, err)
+	require.False(t, seen)
+
+	require.NoError(t, store.MarkSeen("key"))
+
+	seen, err = store.Seen("key")
+	require.NoError(t, err)
+	require.True(t, seen)
+}
+
+func TestFileCursorStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "commercio-sdk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = NewFileCursorStore(filepath.Join(dir, "missing", "cursor"))
+	require.Error(t, err)
+
+	path := filepath.Join(dir, "cursor")
+
+	store, err := NewFileCursorStore(path)
+	require.NoError(t, err)
+
+	require.NoError(t, store.MarkSeen("first"))
+	require.NoError(t, store.MarkSeen("second"))
+	require.NoError(t, store.MarkSeen("first"))
+	require.NoError(t, store.Close())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "first\nsecond\n", string(data))
+
+	// keys are loaded back when the file gets opened again
+	store, err = NewFileCursorStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	for key, want := range map[string]bool{"first": true, "second": true, "third": false} {
+		seen, err := store.Seen(key)
+		require.NoError(t, err)
+		require.Equal(t, want, seen, key)
+	}
+}
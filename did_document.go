@@ -0,0 +1,135 @@
+package commercio
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	id "github.com/commercionetwork/commercionetwork/x/id/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// VerifyDidDocument checks that doc is well-formed and that its proof has been created by the account it
+// describes, over its current content.
+// The proof is verified by recomputing the JSON representation of doc without the proof, as BuildDidDocument
+// does, and by checking the (R || S) signature it contains against it with the secp256k1 public key in
+// Proof.VerificationMethod.
+// ErrInvalidDidDocument is returned if doc is malformed, ErrInvalidProof if its proof doesn't verify.
+func VerifyDidDocument(doc DidDocument) error {
+	if err := validateDidDocument(doc); err != nil {
+		return fmt.Errorf("%w, %s", ErrInvalidDidDocument, err.Error())
+	}
+
+	if err := verifyDidProof(doc); err != nil {
+		return fmt.Errorf("%w, %s", ErrInvalidProof, err.Error())
+	}
+
+	return nil
+}
+
+// validateDidDocument checks the structure of doc: its keys and its proof must be controlled by the account doc
+// describes, key IDs must be in the "<ID>#keys-<index>" form, and RSA keys must be valid PEM-encoded PKIX keys.
+func validateDidDocument(doc DidDocument) error {
+	if doc.Context != id.ContextDidV1 {
+		return fmt.Errorf("invalid context, must be %s", id.ContextDidV1)
+	}
+
+	if doc.ID.Empty() {
+		return errors.New("missing ID")
+	}
+
+	keyPrefix := doc.ID.String() + "#keys-"
+
+	for _, key := range doc.PubKeys {
+		if !doc.ID.Equals(key.Controller) {
+			return fmt.Errorf("key %s: controller %s differs from ID", key.ID, key.Controller)
+		}
+
+		if !strings.HasPrefix(key.ID, keyPrefix) || !isDigits(strings.TrimPrefix(key.ID, keyPrefix)) {
+			return fmt.Errorf("key %s: ID must be in the %s<index> form", key.ID, keyPrefix)
+		}
+
+		switch key.Type {
+		case KeyTypeRsaVerification, KeyTypeRsaSignature:
+			if _, _, err := readKey(strings.NewReader(key.PublicKeyPem), typePublicKey); err != nil {
+				return fmt.Errorf("key %s: %w", key.ID, err)
+			}
+		case KeyTypeSecp256k1, KeyTypeEd25519:
+		default:
+			return fmt.Errorf("key %s: key type %s not supported", key.ID, key.Type)
+		}
+	}
+
+	if !id.PubKeys(doc.PubKeys).HasVerificationAndSignatureKey() {
+		return fmt.Errorf("missing %s key #keys-1 or %s key #keys-2", KeyTypeRsaVerification, KeyTypeRsaSignature)
+	}
+
+	if err := id.Services(doc.Service).Validate(); err != nil {
+		return err
+	}
+
+	if doc.Proof == nil {
+		return errors.New("missing proof")
+	}
+
+	if doc.Proof.Controller != doc.ID.String() {
+		return fmt.Errorf("proof controller %s differs from ID", doc.Proof.Controller)
+	}
+
+	return nil
+}
+
+// verifyDidProof checks the proof of doc against its content.
+func verifyDidProof(doc DidDocument) error {
+	pk, err := types.GetPubKeyFromBech32(types.Bech32PubKeyTypeAccPub, doc.Proof.VerificationMethod)
+	if err != nil {
+		return fmt.Errorf("invalid verification method: %w", err)
+	}
+
+	secpKey, ok := pk.(secp256k1.PubKeySecp256k1)
+	if !ok {
+		return errors.New("verification method is not a secp256k1 public key")
+	}
+
+	if !doc.ID.Equals(types.AccAddress(secpKey.Address())) {
+		return errors.New("verification method doesn't belong to ID")
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(doc.Proof.SignatureValue)
+	if err != nil {
+		return fmt.Errorf("invalid signature value: %w", err)
+	}
+
+	unsigned := doc
+	unsigned.Proof = nil
+
+	data, err := json.Marshal(unsigned)
+	if err != nil {
+		return err
+	}
+
+	// VerifyBytes hashes data with SHA-256, as signWith does
+	if !secpKey.VerifyBytes(data, signature) {
+		return errors.New("signature doesn't match the document")
+	}
+
+	return nil
+}
+
+// isDigits returns true if s is a non-empty string made only of decimal digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
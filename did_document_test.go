@@ -0,0 +1,111 @@
+package commercio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func TestVerifyDidDocument(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	_, rsaKey := testRSAKeypair(t)
+
+	verificationKey, err := sdk.NewDidPubKey(1, KeyTypeRsaVerification, strings.NewReader(rsaKey))
+	require.NoError(t, err)
+	signatureKey, err := sdk.NewDidPubKey(2, KeyTypeRsaSignature, strings.NewReader(rsaKey))
+	require.NoError(t, err)
+
+	_, otherRSAKey := testRSAKeypair(t)
+
+	other := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	// build returns a freshly built DidDocument, modified by tamper before being verified
+	build := func(tamper func(d *DidDocument)) DidDocument {
+		d, err := sdk.BuildDidDocumentWithParams(DidDocumentParams{
+			PubKeys:  []PubKey{verificationKey, signatureKey},
+			Services: []Service{{ID: "delivery", Type: "DocumentDelivery", ServiceEndpoint: "https://example.com/documents"}},
+		})
+		require.NoError(t, err)
+
+		tamper(&d)
+		return d
+	}
+
+	tests := []struct {
+		name    string
+		doc     DidDocument
+		wantErr error
+	}{
+		{
+			"valid document",
+			build(func(d *DidDocument) {}),
+			nil,
+		},
+		{
+			"tampered service",
+			build(func(d *DidDocument) { d.Service[0].ServiceEndpoint = "https://example.com/evil" }),
+			ErrInvalidProof,
+		},
+		{
+			"tampered key",
+			build(func(d *DidDocument) { d.PubKeys[1].PublicKeyPem = otherRSAKey }),
+			ErrInvalidProof,
+		},
+		{
+			"invalid signature encoding",
+			build(func(d *DidDocument) { d.Proof.SignatureValue = "not base64!" }),
+			ErrInvalidProof,
+		},
+		{
+			"proof by another account",
+			build(func(d *DidDocument) {
+				d.Proof.VerificationMethod = "did:com:pub1addwnpepqdr89xxl6pwpj87tzsycmlr035tcmpvcc7xadz5vr2nq9nmcu5hp7xytrlz"
+			}),
+			ErrInvalidProof,
+		},
+		{
+			"key controlled by another account",
+			build(func(d *DidDocument) { d.PubKeys[0].Controller = other }),
+			ErrInvalidDidDocument,
+		},
+		{
+			"proof controlled by another account",
+			build(func(d *DidDocument) { d.Proof.Controller = other.String() }),
+			ErrInvalidDidDocument,
+		},
+		{
+			"malformed key ID",
+			build(func(d *DidDocument) { d.PubKeys[0].ID = sdk.Address + "#key-1" }),
+			ErrInvalidDidDocument,
+		},
+		{
+			"invalid PEM",
+			build(func(d *DidDocument) { d.PubKeys[1].PublicKeyPem = "not a key" }),
+			ErrInvalidDidDocument,
+		},
+		{
+			"missing proof",
+			build(func(d *DidDocument) { d.Proof = nil }),
+			ErrInvalidDidDocument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyDidDocument(tt.doc)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.wantErr), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
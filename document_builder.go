@@ -0,0 +1,191 @@
+package commercio
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/commercionetwork/commercionetwork/x/docs"
+	"github.com/cosmos/cosmos-sdk/types"
+	uuid "github.com/satori/go.uuid"
+)
+
+// DocumentBuilder builds a MsgShareDocument sent by the account associated to an SDK, validating it with the same
+// rules the chain uses before it gets broadcasted.
+// All the DocumentBuilder methods return the builder itself, so that calls can be chained.
+type DocumentBuilder struct {
+	doc  Document
+	errs []string
+}
+
+// NewDocumentBuilder returns a DocumentBuilder for a document sent by the account associated to sdk, with a random
+// UUID.
+func (sdk *SDK) NewDocumentBuilder() *DocumentBuilder {
+	db := &DocumentBuilder{}
+
+	sender, err := types.AccAddressFromBech32(sdk.Address)
+	if err != nil {
+		db.errs = append(db.errs, fmt.Sprintf("invalid sender %s", sdk.Address))
+	}
+
+	db.doc.Sender = sender
+	db.doc.UUID = uuid.NewV4().String()
+
+	return db
+}
+
+// UUID sets the document UUID, overriding the generated one.
+func (db *DocumentBuilder) UUID(documentUUID string) *DocumentBuilder {
+	db.doc.UUID = documentUUID
+	return db
+}
+
+// Recipients adds recipients to the document recipients.
+func (db *DocumentBuilder) Recipients(recipients ...types.AccAddress) *DocumentBuilder {
+	db.doc.Recipients = append(db.doc.Recipients, recipients...)
+	return db
+}
+
+// ContentURI sets the document content URI.
+func (db *DocumentBuilder) ContentURI(uri string) *DocumentBuilder {
+	db.doc.ContentURI = uri
+	return db
+}
+
+// MetadataContentURI sets the document metadata content URI.
+func (db *DocumentBuilder) MetadataContentURI(uri string) *DocumentBuilder {
+	db.doc.Metadata.ContentURI = uri
+	return db
+}
+
+// MetadataSchemaType sets the type of the document metadata schema, which must be one of the schemas supported by
+// the chain.
+// A document metadata can either have a schema type or a custom schema, not both.
+func (db *DocumentBuilder) MetadataSchemaType(schemaType string) *DocumentBuilder {
+	db.doc.Metadata.SchemaType = schemaType
+	return db
+}
+
+// MetadataSchema sets the document metadata custom schema.
+// A document metadata can either have a schema type or a custom schema, not both.
+func (db *DocumentBuilder) MetadataSchema(uri, version string) *DocumentBuilder {
+	db.doc.Metadata.Schema = &docs.DocumentMetadataSchema{
+		URI:     uri,
+		Version: version,
+	}
+
+	return db
+}
+
+// Checksum sets the document checksum.
+func (db *DocumentBuilder) Checksum(value, algorithm string) *DocumentBuilder {
+	db.doc.Checksum = &docs.DocumentChecksum{
+		Value:     value,
+		Algorithm: algorithm,
+	}
+
+	return db
+}
+
+// ChecksumOf sets the document checksum to the one of the content read from r, computed with algorithm.
+func (db *DocumentBuilder) ChecksumOf(r io.Reader, algorithm string) *DocumentBuilder {
+	checksum, err := NewDocumentChecksum(r, algorithm)
+	if err != nil {
+		db.errs = append(db.errs, err.Error())
+		return db
+	}
+
+	return db.Checksum(checksum.Value, checksum.Algorithm)
+}
+
+// Build validates the document and returns it as a MsgShareDocument.
+// The returned error describes every validation failure found.
+func (db *DocumentBuilder) Build() (MsgShareDocument, error) {
+	errs := append(append([]string{}, db.errs...), db.validate()...)
+
+	if len(errs) == 0 {
+		// the chain rules are the last line of defense, in case they get stricter than the ones above
+		if err := docs.Document(db.doc).Validate(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) != 0 {
+		return MsgShareDocument{}, fmt.Errorf("%w, %s", ErrInvalidDocument, strings.Join(errs, "; "))
+	}
+
+	return MsgShareDocument(db.doc), nil
+}
+
+// validate returns a description of each field of the document not complying with the chain rules.
+func (db *DocumentBuilder) validate() []string {
+	var errs []string
+
+	if len(db.doc.Recipients) == 0 {
+		errs = append(errs, "recipients cannot be empty")
+	}
+
+	for i, r := range db.doc.Recipients {
+		if r.Empty() {
+			errs = append(errs, fmt.Sprintf("recipient #%d cannot be empty", i))
+		}
+	}
+
+	if _, err := uuid.FromString(db.doc.UUID); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid UUID %q", db.doc.UUID))
+	}
+
+	if strings.TrimSpace(db.doc.ContentURI) == "" {
+		errs = append(errs, "document content URI cannot be empty")
+	}
+
+	metadata := db.doc.Metadata
+
+	if strings.TrimSpace(metadata.ContentURI) == "" {
+		errs = append(errs, "metadata content URI cannot be empty")
+	}
+
+	hasSchemaType := strings.TrimSpace(metadata.SchemaType) != ""
+
+	switch {
+	case metadata.Schema == nil && !hasSchemaType:
+		errs = append(errs, "either metadata schema or metadata schema type must be defined")
+	case metadata.Schema != nil && hasSchemaType:
+		errs = append(errs, "metadata schema and metadata schema type cannot be both defined")
+	case metadata.Schema != nil:
+		if strings.TrimSpace(metadata.Schema.URI) == "" {
+			errs = append(errs, "metadata schema URI cannot be empty")
+		}
+
+		if strings.TrimSpace(metadata.Schema.Version) == "" {
+			errs = append(errs, "metadata schema version cannot be empty")
+		}
+	}
+
+	if db.doc.Checksum != nil {
+		errs = append(errs, validateChecksum(*db.doc.Checksum)...)
+	}
+
+	return errs
+}
+
+// validateChecksum returns a description of each field of checksum not complying with the chain rules.
+func validateChecksum(checksum docs.DocumentChecksum) []string {
+	algorithm := strings.ToLower(checksum.Algorithm)
+
+	newHash, ok := checksumHashes[algorithm]
+	if !ok {
+		return []string{fmt.Sprintf("unsupported checksum algorithm %q", checksum.Algorithm)}
+	}
+
+	if _, err := hex.DecodeString(checksum.Value); err != nil {
+		return []string{"checksum value must be hex-encoded"}
+	}
+
+	if expected := hex.EncodedLen(newHash().Size()); len(checksum.Value) != expected {
+		return []string{fmt.Sprintf("checksum value must be %d characters long for algorithm %s", expected, algorithm)}
+	}
+
+	return nil
+}
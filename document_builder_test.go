@@ -0,0 +1,131 @@
+package commercio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/commercionetwork/commercionetwork/x/docs"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentBuilder_Build(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	recipient, err := Address(testAddress)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		build    func(db *DocumentBuilder) *DocumentBuilder
+		wantErrs []string
+	}{
+		{
+			"empty document",
+			func(db *DocumentBuilder) *DocumentBuilder {
+				return db
+			},
+			[]string{
+				"recipients cannot be empty",
+				"document content URI cannot be empty",
+				"metadata content URI cannot be empty",
+				"either metadata schema or metadata schema type must be defined",
+			},
+		},
+		{
+			"invalid UUID and empty recipient",
+			func(db *DocumentBuilder) *DocumentBuilder {
+				return db.UUID("uuid").Recipients(types.AccAddress{}).MetadataContentURI("uri").MetadataSchemaType("type")
+			},
+			[]string{
+				"recipient #0 cannot be empty",
+				`invalid UUID "uuid"`,
+			},
+		},
+		{
+			"blank content URI",
+			func(db *DocumentBuilder) *DocumentBuilder {
+				return db.Recipients(recipient).ContentURI(" ").MetadataContentURI("uri").MetadataSchemaType("type")
+			},
+			[]string{"document content URI cannot be empty"},
+		},
+		{
+			"both schema and schema type",
+			func(db *DocumentBuilder) *DocumentBuilder {
+				return db.Recipients(recipient).MetadataContentURI("uri").MetadataSchemaType("type").MetadataSchema("uri", "1.0.0")
+			},
+			[]string{"metadata schema and metadata schema type cannot be both defined"},
+		},
+		{
+			"incomplete schema",
+			func(db *DocumentBuilder) *DocumentBuilder {
+				return db.Recipients(recipient).MetadataContentURI("uri").MetadataSchema(" ", "")
+			},
+			[]string{
+				"metadata schema URI cannot be empty",
+				"metadata schema version cannot be empty",
+			},
+		},
+		{
+			"unsupported checksum algorithm",
+			func(db *DocumentBuilder) *DocumentBuilder {
+				return db.Recipients(recipient).MetadataContentURI("uri").MetadataSchemaType("type").Checksum("aa", "sha-3")
+			},
+			[]string{`unsupported checksum algorithm "sha-3"`},
+		},
+		{
+			"checksum not hex",
+			func(db *DocumentBuilder) *DocumentBuilder {
+				return db.Recipients(recipient).MetadataContentURI("uri").MetadataSchemaType("type").Checksum("zz", "md5")
+			},
+			[]string{"checksum value must be hex-encoded"},
+		},
+		{
+			"checksum of the wrong length",
+			func(db *DocumentBuilder) *DocumentBuilder {
+				return db.Recipients(recipient).MetadataContentURI("uri").MetadataSchemaType("type").Checksum("900150983cd24fb0d6963f7d28e17f72", "sha-256")
+			},
+			[]string{"checksum value must be 64 characters long for algorithm sha-256"},
+		},
+		{
+			"checksum of unreadable content",
+			func(db *DocumentBuilder) *DocumentBuilder {
+				return db.Recipients(recipient).MetadataContentURI("uri").MetadataSchemaType("type").ChecksumOf(failingReader{}, "md5")
+			},
+			[]string{"invalid checksum"},
+		},
+		{
+			"all ok",
+			func(db *DocumentBuilder) *DocumentBuilder {
+				return db.
+					Recipients(recipient).
+					ContentURI("https://example.com/document").
+					MetadataContentURI("https://example.com/metadata").
+					MetadataSchema("https://example.com/schema", "1.0.0").
+					ChecksumOf(strings.NewReader("abc"), "md5")
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.build(sdk.NewDocumentBuilder()).Build()
+
+			if tt.wantErrs != nil {
+				require.True(t, errors.Is(err, ErrInvalidDocument))
+				for _, wantErr := range tt.wantErrs {
+					require.Contains(t, err.Error(), wantErr)
+				}
+				require.Equal(t, MsgShareDocument{}, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, sdk.Address, res.Sender.String())
+			require.Equal(t, "900150983cd24fb0d6963f7d28e17f72", res.Checksum.Value)
+			require.NoError(t, docs.MsgShareDocument(res).ValidateBasic())
+		})
+	}
+}
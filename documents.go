@@ -0,0 +1,399 @@
+package commercio
+
+import (
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/commercionetwork/commercionetwork/x/docs"
+	"github.com/cosmos/cosmos-sdk/types"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Document fields that can be encrypted by BuildEncryptedShareDocument.
+const (
+	// EncryptedFieldContent represents the document file content, which is not stored on chain.
+	EncryptedFieldContent = "content"
+
+	// EncryptedFieldContentURI represents the document content URI.
+	EncryptedFieldContentURI = "content_uri"
+
+	// EncryptedFieldMetadataContentURI represents the document metadata content URI.
+	EncryptedFieldMetadataContentURI = "metadata.content_uri"
+
+	// EncryptedFieldMetadataSchemaURI represents the document metadata schema URI.
+	EncryptedFieldMetadataSchemaURI = "metadata.schema.uri"
+
+	// encryptedFieldChecksumValue represents the document checksum value, which x/docs doesn't accept as an
+	// encrypted field yet: it is only supported by DecryptDocument.
+	encryptedFieldChecksumValue = "checksum.value"
+)
+
+// DocumentRecipient is a recipient of an encrypted document.
+type DocumentRecipient struct {
+	// Address is the address of the recipient.
+	Address types.AccAddress
+
+	// EncryptionKey is the recipient RSA PKIX public key, usually the verification key found in its DidDocument.
+	EncryptionKey io.Reader
+}
+
+// ShareDocumentParams are parameters used by BuildEncryptedShareDocument during its lifecycle.
+type ShareDocumentParams struct {
+	// UUID is the document UUID, a random one is generated if empty.
+	UUID string
+
+	// Metadata holds the plaintext document metadata.
+	Metadata DocumentMetadata
+
+	// ContentURI is the plaintext document content URI.
+	ContentURI string
+
+	// Checksum is the checksum of the plaintext document content, if any.
+	Checksum *DocumentChecksum
+
+	// Recipients are the document recipients, each one of them will be able to decrypt the document.
+	Recipients []DocumentRecipient
+
+	// EncryptedFields lists the document fields to be encrypted.
+	// If empty, content URI and metadata content URI are encrypted.
+	EncryptedFields []string
+
+	// Content is the document file content, if any.
+	// When set, its encrypted representation is written to EncryptedContent.
+	Content io.Reader
+
+	// EncryptedContent receives the encrypted document file content, to be stored off-chain.
+	EncryptedContent io.Writer
+}
+
+// encryptedFields returns the fields to be encrypted, in the order they must appear in the document.
+func (p ShareDocumentParams) encryptedFields() []string {
+	fields := p.EncryptedFields
+	if len(fields) == 0 {
+		fields = []string{EncryptedFieldMetadataContentURI}
+		if p.ContentURI != "" {
+			fields = append([]string{EncryptedFieldContentURI}, fields...)
+		}
+	}
+
+	if p.Content != nil && !containsString(fields, EncryptedFieldContent) {
+		fields = append([]string{EncryptedFieldContent}, fields...)
+	}
+
+	return fields
+}
+
+// validate checks that each and every field of p are complying with the specification.
+func (p ShareDocumentParams) validate() error {
+	if len(p.Recipients) == 0 {
+		return errors.New("recipients cannot be empty")
+	}
+
+	for i, r := range p.Recipients {
+		if r.Address.Empty() {
+			return fmt.Errorf("recipient #%d address cannot be empty", i)
+		}
+
+		if r.EncryptionKey == nil {
+			return fmt.Errorf("recipient #%d encryption key cannot be nil", i)
+		}
+	}
+
+	if (p.Content == nil) != (p.EncryptedContent == nil) {
+		return errors.New("content and encrypted content must be both set or both nil")
+	}
+
+	for _, f := range p.EncryptedFields {
+		switch f {
+		case EncryptedFieldContentURI:
+			if p.ContentURI == "" {
+				return fmt.Errorf("field %s marked as encrypted but empty", f)
+			}
+		case EncryptedFieldMetadataSchemaURI:
+			if p.Metadata.Schema == nil || p.Metadata.Schema.URI == "" {
+				return fmt.Errorf("field %s marked as encrypted but empty", f)
+			}
+		case EncryptedFieldContent:
+			if p.Content == nil {
+				return fmt.Errorf("field %s marked as encrypted but empty", f)
+			}
+		case EncryptedFieldMetadataContentURI:
+		default:
+			return fmt.Errorf("unsupported encrypted field %s", f)
+		}
+	}
+
+	return nil
+}
+
+// documentEncryptionKey mirrors the x/docs DocumentEncryptionKey JSON representation.
+type documentEncryptionKey struct {
+	Recipient types.AccAddress `json:"recipient"`
+	Value     string           `json:"value"`
+}
+
+// documentEncryptionData mirrors the x/docs DocumentEncryptionData JSON representation, whose type is not exported
+// by the x/docs module.
+type documentEncryptionData struct {
+	Keys          []documentEncryptionKey `json:"keys"`
+	EncryptedData []string                `json:"encrypted_data"`
+}
+
+// BuildEncryptedShareDocument creates a MsgShareDocument sent by the account associated to sdk, whose fields are
+// encrypted with a random AES-256 key as described by params.
+// The AES key is encrypted with the RSA public key of each recipient, so that each one of them can decrypt the
+// document.
+func (sdk *SDK) BuildEncryptedShareDocument(params ShareDocumentParams) (MsgShareDocument, error) {
+	e := func(w error, ext error) (MsgShareDocument, error) {
+		return MsgShareDocument{}, fmt.Errorf("%w, %s", w, ext.Error())
+	}
+
+	if err := params.validate(); err != nil {
+		return e(ErrInvalidDocument, err)
+	}
+
+	sender, err := types.AccAddressFromBech32(sdk.signer.Address())
+	if err != nil {
+		return e(ErrInvalidAddress, err)
+	}
+
+	documentUUID := params.UUID
+	if documentUUID == "" {
+		documentUUID = uuid.NewV4().String()
+	}
+
+	key, err := newAESKey()
+	if err != nil {
+		return MsgShareDocument{}, err
+	}
+
+	encryptionData := documentEncryptionData{
+		EncryptedData: params.encryptedFields(),
+	}
+
+	doc := Document{
+		Sender:     sender,
+		UUID:       documentUUID,
+		Metadata:   docs.DocumentMetadata(params.Metadata),
+		ContentURI: params.ContentURI,
+		Checksum:   (*docs.DocumentChecksum)(params.Checksum),
+	}
+
+	if params.Metadata.Schema != nil {
+		// don't modify the schema owned by the caller
+		schema := *params.Metadata.Schema
+		doc.Metadata.Schema = &schema
+	}
+
+	for _, field := range encryptionData.EncryptedData {
+		switch field {
+		case EncryptedFieldContent:
+			err = encryptContent(key, params.Content, params.EncryptedContent)
+		case EncryptedFieldContentURI:
+			doc.ContentURI, err = encryptField(key, doc.ContentURI)
+		case EncryptedFieldMetadataContentURI:
+			doc.Metadata.ContentURI, err = encryptField(key, doc.Metadata.ContentURI)
+		case EncryptedFieldMetadataSchemaURI:
+			doc.Metadata.Schema.URI, err = encryptField(key, doc.Metadata.Schema.URI)
+		}
+
+		if err != nil {
+			return MsgShareDocument{}, err
+		}
+	}
+
+	for _, r := range params.Recipients {
+		encryptedKey, err := encryptKeyFor(key, r.EncryptionKey, sdk.config.CryptoProfile)
+		if err != nil {
+			return e(ErrInvalidEncryptionKey, fmt.Errorf("recipient %s: %w", r.Address.String(), err))
+		}
+
+		doc.Recipients = append(doc.Recipients, r.Address)
+		encryptionData.Keys = append(encryptionData.Keys, documentEncryptionKey{
+			Recipient: r.Address,
+			Value:     encryptedKey,
+		})
+	}
+
+	rawEncryptionData, err := json.Marshal(encryptionData)
+	if err != nil {
+		return e(ErrEncryptionFailure, err)
+	}
+
+	if err := json.Unmarshal(rawEncryptionData, &doc.EncryptionData); err != nil {
+		return e(ErrEncryptionFailure, err)
+	}
+
+	if err := docs.Document(doc).Validate(); err != nil {
+		return e(ErrInvalidDocument, err)
+	}
+
+	return MsgShareDocument(doc), nil
+}
+
+// DecryptDocument returns doc with its encrypted fields decrypted, using the document key encrypted for the
+// account associated to sdk.
+// rsaPrivateKey is the RSA PKCS8 private key associated to the public key the document key has been encrypted with.
+// doc is returned unchanged if it is not encrypted.
+func (sdk *SDK) DecryptDocument(doc Document, rsaPrivateKey io.Reader) (Document, error) {
+	e := func(w error, ext error) (Document, error) {
+		return Document{}, fmt.Errorf("%w, %s", w, ext.Error())
+	}
+
+	if doc.EncryptionData == nil {
+		return doc, nil
+	}
+
+	var encryptedKey string
+	for _, k := range doc.EncryptionData.Keys {
+		if k.Recipient.String() == sdk.Address {
+			encryptedKey = k.Value
+			break
+		}
+	}
+
+	if encryptedKey == "" {
+		return Document{}, ErrNotRecipient
+	}
+
+	key, err := decryptKeyWith(encryptedKey, rsaPrivateKey, sdk.config.CryptoProfile)
+	if err != nil {
+		return e(ErrDecryptionFailure, err)
+	}
+
+	// copy the pointed values, so that doc doesn't get modified
+	if doc.Metadata.Schema != nil {
+		schema := *doc.Metadata.Schema
+		doc.Metadata.Schema = &schema
+	}
+
+	if doc.Checksum != nil {
+		checksum := *doc.Checksum
+		doc.Checksum = &checksum
+	}
+
+	for _, field := range doc.EncryptionData.EncryptedData {
+		switch field {
+		case EncryptedFieldContentURI:
+			doc.ContentURI, err = decryptField(key, doc.ContentURI)
+		case EncryptedFieldMetadataContentURI:
+			doc.Metadata.ContentURI, err = decryptField(key, doc.Metadata.ContentURI)
+		case EncryptedFieldMetadataSchemaURI:
+			if doc.Metadata.Schema != nil {
+				doc.Metadata.Schema.URI, err = decryptField(key, doc.Metadata.Schema.URI)
+			}
+		case encryptedFieldChecksumValue:
+			if doc.Checksum != nil {
+				doc.Checksum.Value, err = decryptField(key, doc.Checksum.Value)
+			}
+		}
+
+		if err != nil {
+			return e(err, fmt.Errorf("field %s", field))
+		}
+	}
+
+	return doc, nil
+}
+
+// encryptField encrypts value with key, and returns its hex-encoded representation.
+func encryptField(key []byte, value string) (string, error) {
+	ciphertext, err := aesEncrypt(key, []byte(value))
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(ciphertext), nil
+}
+
+// encryptContent encrypts the content read from r with key, and writes it to w.
+func encryptContent(key []byte, r io.Reader, w io.Writer) error {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("%w, cannot read content, %s", ErrEncryptionFailure, err.Error())
+	}
+
+	ciphertext, err := aesEncrypt(key, content)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(ciphertext); err != nil {
+		return fmt.Errorf("%w, cannot write encrypted content, %s", ErrEncryptionFailure, err.Error())
+	}
+
+	return nil
+}
+
+// decryptField decrypts the hex-encoded value with key.
+func decryptField(key []byte, value string) (string, error) {
+	ciphertext, err := hex.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("%w, %s", ErrTamperedCiphertext, err.Error())
+	}
+
+	plaintext, err := aesDecrypt(key, ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// decryptKeyWith decrypts the hex-encoded encryptedKey with the RSA PKCS8 private key read from r, using the
+// scheme selected by profile.
+func decryptKeyWith(encryptedKey string, r io.Reader, profile CryptoProfile) ([]byte, error) {
+	_, rawKey, err := readKey(r, typePrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := rawKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA private key")
+	}
+
+	ciphertext, err := hex.DecodeString(encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return profile.decrypt(privateKey, ciphertext)
+}
+
+// encryptKeyFor encrypts key with the RSA PKIX public key read from r using the scheme selected by profile, and
+// returns its hex-encoded representation.
+func encryptKeyFor(key []byte, r io.Reader, profile CryptoProfile) (string, error) {
+	_, rawKey, err := readKey(r, typePublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	publicKey, ok := rawKey.(*rsa.PublicKey)
+	if !ok {
+		return "", errors.New("not an RSA public key")
+	}
+
+	encryptedKey, err := profile.encrypt(publicKey, key)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(encryptedKey), nil
+}
+
+// containsString returns true if s contains v.
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
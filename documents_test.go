@@ -0,0 +1,315 @@
+package commercio
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/commercionetwork/commercionetwork/x/docs"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// testRSAKeypair returns a new PEM-encoded RSA private and public key.
+func testRSAKeypair(t *testing.T) (string, string) {
+	privateKey, publicKey, err := NewRSAKeypair()
+	require.NoError(t, err)
+
+	return privateKey, publicKey
+}
+
+// decryptForTest decrypts the nonce-prefixed AES-GCM ciphertext with key.
+func decryptForTest(t *testing.T, key, ciphertext []byte) string {
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+
+	aesgcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	plaintext, err := aesgcm.Open(nil, ciphertext[:aesgcm.NonceSize()], ciphertext[aesgcm.NonceSize():], nil)
+	require.NoError(t, err)
+
+	return string(plaintext)
+}
+
+func TestSDK_BuildEncryptedShareDocument(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	recipient, err := Address(testAddress)
+	require.NoError(t, err)
+
+	privateKey, publicKey := testRSAKeypair(t)
+
+	metadata := DocumentMetadata{
+		ContentURI: "https://example.com/metadata",
+		Schema: &docs.DocumentMetadataSchema{
+			URI:     "https://example.com/schema",
+			Version: "1.0.0",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		params  func() ShareDocumentParams
+		wantErr bool
+	}{
+		{
+			"missing recipients",
+			func() ShareDocumentParams {
+				return ShareDocumentParams{Metadata: metadata}
+			},
+			true,
+		},
+		{
+			"invalid recipient key",
+			func() ShareDocumentParams {
+				return ShareDocumentParams{
+					Metadata:   metadata,
+					Recipients: []DocumentRecipient{{Address: recipient, EncryptionKey: strings.NewReader("aaa")}},
+				}
+			},
+			true,
+		},
+		{
+			"content without encrypted content writer",
+			func() ShareDocumentParams {
+				return ShareDocumentParams{
+					Metadata:   metadata,
+					Recipients: []DocumentRecipient{{Address: recipient, EncryptionKey: strings.NewReader(publicKey)}},
+					Content:    strings.NewReader("content"),
+				}
+			},
+			true,
+		},
+		{
+			"unsupported encrypted field",
+			func() ShareDocumentParams {
+				return ShareDocumentParams{
+					Metadata:        metadata,
+					Recipients:      []DocumentRecipient{{Address: recipient, EncryptionKey: strings.NewReader(publicKey)}},
+					EncryptedFields: []string{"checksum"},
+				}
+			},
+			true,
+		},
+		{
+			"empty field marked as encrypted",
+			func() ShareDocumentParams {
+				return ShareDocumentParams{
+					Metadata:        metadata,
+					Recipients:      []DocumentRecipient{{Address: recipient, EncryptionKey: strings.NewReader(publicKey)}},
+					EncryptedFields: []string{EncryptedFieldContentURI},
+				}
+			},
+			true,
+		},
+		{
+			"invalid metadata",
+			func() ShareDocumentParams {
+				return ShareDocumentParams{
+					Recipients: []DocumentRecipient{{Address: recipient, EncryptionKey: strings.NewReader(publicKey)}},
+				}
+			},
+			true,
+		},
+		{
+			"all ok",
+			func() ShareDocumentParams {
+				return ShareDocumentParams{
+					Metadata:         metadata,
+					ContentURI:       "https://example.com/document",
+					Recipients:       []DocumentRecipient{{Address: recipient, EncryptionKey: strings.NewReader(publicKey)}},
+					EncryptedFields:  []string{EncryptedFieldContentURI, EncryptedFieldMetadataContentURI, EncryptedFieldMetadataSchemaURI},
+					Content:          strings.NewReader("content"),
+					EncryptedContent: &bytes.Buffer{},
+				}
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params()
+
+			res, err := sdk.BuildEncryptedShareDocument(params)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, MsgShareDocument{}, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, sdk.Address, res.Sender.String())
+			require.Len(t, res.Recipients, 1)
+			require.Len(t, res.EncryptionData.Keys, 1)
+			require.Equal(t, []string{
+				EncryptedFieldContent,
+				EncryptedFieldContentURI,
+				EncryptedFieldMetadataContentURI,
+				EncryptedFieldMetadataSchemaURI,
+			}, res.EncryptionData.EncryptedData)
+
+			// the caller schema must not be modified
+			require.Equal(t, "https://example.com/schema", metadata.Schema.URI)
+
+			_, rawKey, err := readKey(strings.NewReader(privateKey), typePrivateKey)
+			require.NoError(t, err)
+
+			encryptedKey, err := hex.DecodeString(res.EncryptionData.Keys[0].Value)
+			require.NoError(t, err)
+
+			key, err := rsa.DecryptPKCS1v15(rand.Reader, rawKey.(*rsa.PrivateKey), encryptedKey)
+			require.NoError(t, err)
+
+			for expected, field := range map[string]string{
+				"https://example.com/document": res.ContentURI,
+				"https://example.com/metadata": res.Metadata.ContentURI,
+				"https://example.com/schema":   res.Metadata.Schema.URI,
+			} {
+				ciphertext, err := hex.DecodeString(field)
+				require.NoError(t, err)
+				require.Equal(t, expected, decryptForTest(t, key, ciphertext))
+			}
+
+			require.Equal(t, "content", decryptForTest(t, key, params.EncryptedContent.(*bytes.Buffer).Bytes()))
+		})
+	}
+}
+
+func TestSDK_BuildEncryptedShareDocument_errors(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	recipient, err := Address(testAddress)
+	require.NoError(t, err)
+
+	_, err = sdk.BuildEncryptedShareDocument(ShareDocumentParams{
+		Metadata:   DocumentMetadata{ContentURI: "https://example.com/metadata", SchemaType: "type"},
+		Recipients: []DocumentRecipient{{Address: recipient, EncryptionKey: strings.NewReader("aaa")}},
+	})
+	require.True(t, errors.Is(err, ErrInvalidEncryptionKey))
+
+	_, err = sdk.BuildEncryptedShareDocument(ShareDocumentParams{})
+	require.True(t, errors.Is(err, ErrInvalidDocument))
+}
+
+func TestSDK_DecryptDocument(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	self, err := Address(sdk.Address)
+	require.NoError(t, err)
+
+	other := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	privateKey, publicKey := testRSAKeypair(t)
+	otherPrivateKey, _ := testRSAKeypair(t)
+
+	build := func(recipient DocumentRecipient) Document {
+		msg, err := sdk.BuildEncryptedShareDocument(ShareDocumentParams{
+			Metadata: DocumentMetadata{
+				ContentURI: "https://example.com/metadata",
+				Schema: &docs.DocumentMetadataSchema{
+					URI:     "https://example.com/schema",
+					Version: "1.0.0",
+				},
+			},
+			ContentURI:      "https://example.com/document",
+			Recipients:      []DocumentRecipient{recipient},
+			EncryptedFields: []string{EncryptedFieldContentURI, EncryptedFieldMetadataContentURI, EncryptedFieldMetadataSchemaURI},
+		})
+		require.NoError(t, err)
+
+		return Document(msg)
+	}
+
+	tests := []struct {
+		name       string
+		doc        func() Document
+		privateKey string
+		wantErr    error
+	}{
+		{
+			"not encrypted",
+			func() Document {
+				return Document{ContentURI: "https://example.com/document"}
+			},
+			privateKey,
+			nil,
+		},
+		{
+			"not a recipient",
+			func() Document {
+				return build(DocumentRecipient{Address: other, EncryptionKey: strings.NewReader(publicKey)})
+			},
+			privateKey,
+			ErrNotRecipient,
+		},
+		{
+			"wrong private key",
+			func() Document {
+				return build(DocumentRecipient{Address: self, EncryptionKey: strings.NewReader(publicKey)})
+			},
+			otherPrivateKey,
+			ErrDecryptionFailure,
+		},
+		{
+			"tampered ciphertext",
+			func() Document {
+				doc := build(DocumentRecipient{Address: self, EncryptionKey: strings.NewReader(publicKey)})
+
+				ciphertext, err := hex.DecodeString(doc.ContentURI)
+				require.NoError(t, err)
+				ciphertext[len(ciphertext)-1] ^= 0xff
+				doc.ContentURI = hex.EncodeToString(ciphertext)
+
+				return doc
+			},
+			privateKey,
+			ErrTamperedCiphertext,
+		},
+		{
+			"encrypted for the sdk account",
+			func() Document {
+				return build(DocumentRecipient{Address: self, EncryptionKey: strings.NewReader(publicKey)})
+			},
+			privateKey,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := tt.doc()
+
+			res, err := sdk.DecryptDocument(doc, strings.NewReader(tt.privateKey))
+
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr))
+				require.Equal(t, Document{}, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "https://example.com/document", res.ContentURI)
+
+			if doc.EncryptionData == nil {
+				return
+			}
+
+			require.Equal(t, "https://example.com/metadata", res.Metadata.ContentURI)
+			require.Equal(t, "https://example.com/schema", res.Metadata.Schema.URI)
+
+			// the original document must not be modified
+			require.NotEqual(t, "https://example.com/schema", doc.Metadata.Schema.URI)
+		})
+	}
+}
@@ -39,4 +39,114 @@ var (
 
 	// ErrEncryptionFailure represents an error returned when some error happens during the encryption process.
 	ErrEncryptionFailure = errors.New("encryption failure")
+
+	// ErrQuery represents an error returned when a query to the LCD fails, or its response cannot be decoded.
+	ErrQuery = errors.New("query failed")
+
+	// ErrInvalidFee represents an error returned when the fee provided for a transaction is invalid.
+	ErrInvalidFee = errors.New("invalid fee")
+
+	// ErrSimulation represents an error returned when a transaction simulation fails.
+	ErrSimulation = errors.New("transaction simulation failed")
+
+	// ErrTxFailed represents an error returned when a transaction has been included in a block, but its execution
+	// failed.
+	ErrTxFailed = errors.New("transaction failed")
+
+	// ErrTxTimeout represents an error returned when a transaction isn't included in a block in the given time.
+	ErrTxTimeout = errors.New("transaction not included in a block in time")
+
+	// ErrSigning represents an error returned when a transaction cannot be signed.
+	ErrSigning = errors.New("could not sign transaction")
+
+	// ErrBroadcast represents an error returned when a transaction cannot be broadcasted, or the LCD rejects it.
+	ErrBroadcast = errors.New("could not broadcast transaction")
+
+	// ErrChainIDMismatch represents an error returned when the SDK is asked to operate on a chain different from
+	// the configured one.
+	ErrChainIDMismatch = errors.New("unexpected chain ID")
+
+	// ErrInvalidPrivateKey represents an error returned when a private key is not a valid secp256k1 private key.
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+
+	// ErrKeystore represents an error returned when a keystore cannot be read or written.
+	ErrKeystore = errors.New("keystore error")
+
+	// ErrInvalidDocument represents an error returned when a document cannot be built, or is not valid.
+	ErrInvalidDocument = errors.New("invalid document")
+
+	// ErrInvalidEncryptionKey represents an error returned when the RSA encryption key of a document recipient
+	// is invalid.
+	ErrInvalidEncryptionKey = errors.New("invalid encryption key")
+
+	// ErrDecryptionFailure represents an error returned when some error happens during the decryption process.
+	ErrDecryptionFailure = errors.New("decryption failure")
+
+	// ErrNotRecipient represents an error returned when decrypting a document the SDK account is not a
+	// recipient of.
+	ErrNotRecipient = errors.New("not a document recipient")
+
+	// ErrTamperedCiphertext represents an error returned when an encrypted value fails authentication, because
+	// it has been modified or encrypted with a different key.
+	ErrTamperedCiphertext = errors.New("tampered ciphertext")
+
+	// ErrInvalidChecksum represents an error returned when a document checksum cannot be computed.
+	ErrInvalidChecksum = errors.New("invalid checksum")
+
+	// ErrChecksumMismatch represents an error returned when a document content doesn't match its checksum.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
+	// ErrInvalidReceipt represents an error returned when a document receipt cannot be built, or is not valid.
+	ErrInvalidReceipt = errors.New("invalid document receipt")
+
+	// ErrSubscription represents an error returned when subscribing to Tendermint events fails, or an event
+	// cannot be decoded.
+	ErrSubscription = errors.New("subscription failed")
+
+	// ErrInvalidDidDocument represents an error returned when a DidDocument cannot be built, or is not valid.
+	ErrInvalidDidDocument = errors.New("invalid did document")
+
+	// ErrInvalidProof represents an error returned when the proof of a DidDocument doesn't match its content.
+	ErrInvalidProof = errors.New("invalid proof")
+
+	// ErrInvalidProofOptions represents an error returned when the options used to open a power-up proof are
+	// invalid.
+	ErrInvalidProofOptions = errors.New("invalid proof options")
+
+	// ErrKeyGeneration represents an error returned when a new RSA keypair cannot be generated.
+	ErrKeyGeneration = errors.New("cannot generate keys")
+
+	// ErrPairwise represents an error returned when a pairwise identity cannot be derived or recorded.
+	ErrPairwise = errors.New("pairwise identity error")
+
+	// ErrPowerUpStore represents an error returned when a power-up request cannot be recorded in, read from or
+	// removed from a PowerUpStore.
+	ErrPowerUpStore = errors.New("power-up store error")
 )
+
+// wrappedError is an error returned by an operation failed because of cause, matching both op and cause with
+// errors.Is, e.g. both ErrNewSDK and ErrChainIDMismatch.
+type wrappedError struct {
+	op    error
+	cause error
+}
+
+// wrap returns an error matching both op and cause with errors.Is.
+func wrap(op, cause error) error {
+	return wrappedError{op: op, cause: cause}
+}
+
+// Error implements error.
+func (e wrappedError) Error() string {
+	return e.op.Error() + ", " + e.cause.Error()
+}
+
+// Unwrap returns the failed operation error.
+func (e wrappedError) Unwrap() error {
+	return e.op
+}
+
+// Is reports whether the cause of e matches target.
+func (e wrappedError) Is(target error) bool {
+	return errors.Is(e.cause, target)
+}
@@ -0,0 +1,308 @@
+package commercio
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// default fee denomination
+	feeDenom = "ucommercio"
+
+	// default fee amount charged for each message
+	feeAmount = 10000
+
+	// default gas limit for each transaction
+	feeGas = 200000
+
+	// default gas adjustment applied to simulated gas
+	feeGasAdjustment = 1.5
+
+	// default maximum gas limit for transactions built by batching messages
+	feeMaxGas = 2000000
+)
+
+var (
+	// DefaultFeePolicy represents the default fee policy for a commercio.network SDK instance: 10000ucommercio for
+	// each message contained in a transaction, and 200000 gas for the whole transaction.
+	DefaultFeePolicy = FeePolicy{
+		Mode:          FeeModeFixed,
+		Denom:         feeDenom,
+		Amount:        feeAmount,
+		Gas:           feeGas,
+		GasAdjustment: feeGasAdjustment,
+		MaxGas:        feeMaxGas,
+	}
+)
+
+// FeeMode represents the way the SDK computes the fee amount for a transaction.
+// FeeMode can be either:
+//   - `fixed`: each message is charged FeePolicy.Amount.
+//   - `table`: each message is charged the amount associated to its type in FeePolicy.MsgAmounts, or FeePolicy.Amount
+//     if its type is not listed there.
+//   - `gasprice`: the transaction is charged FeePolicy.GasPrice times its gas limit.
+type FeeMode string
+
+const (
+	// FeeModeFixed represents the `fixed` fee mode.
+	FeeModeFixed = "fixed"
+
+	// FeeModeTable represents the `table` fee mode.
+	FeeModeTable = "table"
+
+	// FeeModeGasPrice represents the `gasprice` fee mode.
+	FeeModeGasPrice = "gasprice"
+)
+
+// FeePolicy defines how the SDK computes fee and gas limit for each transaction it sends.
+type FeePolicy struct {
+	// Mode is the FeeMode used to compute the fee amount.
+	Mode FeeMode
+
+	// Denom is the denomination in which fees are paid.
+	Denom string
+
+	// Amount is the fee charged for each message when Mode is FeeModeFixed, and for each message whose type is not
+	// listed in MsgAmounts when Mode is FeeModeTable.
+	Amount uint64
+
+	// MsgAmounts associates a Cosmos message type (e.g. "commercio/MsgShareDocument") to the fee charged for each
+	// message of that type, used when Mode is FeeModeTable.
+	MsgAmounts map[string]uint64
+
+	// GasPrice is the price of a single gas unit, used when Mode is FeeModeGasPrice.
+	GasPrice types.Dec
+
+	// Gas is the gas limit for each transaction, regardless of the number of its messages.
+	Gas uint64
+
+	// GasPerMsg, when non-zero, is the gas limit for each message: a transaction gas limit is GasPerMsg times the
+	// number of its messages, instead of Gas.
+	GasPerMsg uint64
+
+	// Simulate, when true, makes the SDK simulate each transaction before signing it, and use the simulated gas
+	// multiplied by GasAdjustment as gas limit instead of Gas.
+	Simulate bool
+
+	// GasAdjustment is the factor the simulated gas gets multiplied by when Simulate is true.
+	GasAdjustment float64
+
+	// MaxGas is the maximum gas limit of the transactions the SDK builds by batching many messages, e.g. in
+	// AcknowledgeDocuments: each transaction contains at most MaxGas / GasPerMsg messages, or MaxGas divided by
+	// the adjusted simulated gas of a single message when Simulate is true.
+	// When neither GasPerMsg nor Simulate are set, each message is assumed to need Gas: transactions contain at
+	// most MaxGas / Gas messages, and their gas limit is Gas times the number of their messages.
+	// If zero, all the messages are sent in a single transaction.
+	MaxGas uint64
+}
+
+// withDefaults returns DefaultFeePolicy if fp is the zero FeePolicy, fp otherwise.
+func (fp FeePolicy) withDefaults() FeePolicy {
+	if reflect.DeepEqual(fp, FeePolicy{}) {
+		return DefaultFeePolicy
+	}
+
+	return fp
+}
+
+// validate checks that fp is complying with the specification of its Mode.
+func (fp FeePolicy) validate() error {
+	if fp.Denom == "" {
+		return errors.New("missing fee denom")
+	}
+
+	if fp.Gas == 0 && fp.GasPerMsg == 0 {
+		return errors.New("gas limit cannot be zero")
+	}
+
+	if fp.MaxGas != 0 && fp.MaxGas < fp.GasPerMsg {
+		return errors.New("max gas cannot be lower than the gas limit of a single message")
+	}
+
+	if fp.Simulate && fp.GasAdjustment <= 0 {
+		return errors.New("gas adjustment must be positive")
+	}
+
+	switch fp.Mode {
+	case FeeModeFixed:
+	case FeeModeTable:
+		if len(fp.MsgAmounts) == 0 {
+			return errors.New("missing per-message fee table")
+		}
+	case FeeModeGasPrice:
+		if fp.GasPrice.IsNil() || !fp.GasPrice.IsPositive() {
+			return errors.New("gas price must be positive")
+		}
+	default:
+		return errors.New("invalid fee mode")
+	}
+
+	return nil
+}
+
+// fee returns the fee for a transaction containing messages of msgTypes Cosmos types, as defined by fp.
+func (fp FeePolicy) fee(msgTypes []string) sacco.Fee {
+	gas := fp.Gas
+	if fp.GasPerMsg != 0 {
+		gas = fp.GasPerMsg * uint64(len(msgTypes))
+	}
+
+	var amount uint64
+	switch fp.Mode {
+	case FeeModeFixed:
+		amount = fp.Amount * uint64(len(msgTypes))
+	case FeeModeTable:
+		for _, t := range msgTypes {
+			a, ok := fp.MsgAmounts[t]
+			if !ok {
+				a = fp.Amount
+			}
+
+			amount += a
+		}
+	case FeeModeGasPrice:
+		amount = gasPriceAmount(fp.GasPrice, gas)
+	}
+
+	return Fee{
+		Amount: amount,
+		Denom:  fp.Denom,
+		Gas:    gas,
+	}.asSaccoFee()
+}
+
+// batchSize returns the maximum number of messages needing msgGas gas each to be sent in a single transaction when
+// batching them, or zero if there's no limit.
+// At least one message is sent in each transaction, even if it needs more than MaxGas.
+func (fp FeePolicy) batchSize(msgGas uint64) int {
+	if fp.MaxGas == 0 || msgGas == 0 {
+		return 0
+	}
+
+	if msgGas > fp.MaxGas {
+		return 1
+	}
+
+	return int(fp.MaxGas / msgGas)
+}
+
+// batchSize returns the maximum number of messages like msg to be sent in a single transaction when batching them,
+// or zero if there's no limit.
+// If the FeePolicy requires simulation, the gas needed by each message is estimated by simulating a transaction
+// containing msg alone, otherwise it is FeePolicy.GasPerMsg, or FeePolicy.Gas if the former is zero.
+func (sdk *SDK) batchSize(msg interface{}) (int, error) {
+	fp := sdk.config.FeePolicy
+
+	if fp.MaxGas == 0 || !fp.Simulate {
+		if fp.GasPerMsg == 0 {
+			return fp.batchSize(fp.Gas), nil
+		}
+
+		return fp.batchSize(fp.GasPerMsg), nil
+	}
+
+	gasUsed, err := sdk.SimulateTransaction(msg)
+	if err != nil {
+		return 0, err
+	}
+
+	return fp.batchSize(adjustedGas(gasUsed, fp.GasAdjustment)), nil
+}
+
+// sendBatch sends rawMsgs in a single transaction, like SendTransaction does.
+// If the FeePolicy gas limit doesn't depend on the messages, the transaction gas limit is FeePolicy.Gas times the
+// number of messages, as assumed by batchSize.
+func (sdk *SDK) sendBatch(rawMsgs ...interface{}) (string, error) {
+	txp, err := sdk.prepareTx(rawMsgs...)
+	if err != nil {
+		return "", err
+	}
+
+	fp := sdk.config.FeePolicy
+	if !fp.Simulate && fp.GasPerMsg == 0 {
+		txp.Fee = fp.withGas(txp.Fee, fp.Gas*uint64(len(rawMsgs)))
+	}
+
+	return sdk.signAndBroadcast(txp)
+}
+
+// withGas returns fee with gas as gas limit, recomputing its amount if fp depends on the gas limit.
+func (fp FeePolicy) withGas(fee sacco.Fee, gas uint64) sacco.Fee {
+	fee.Gas = strconv.FormatUint(gas, 10)
+
+	if fp.Mode == FeeModeGasPrice {
+		fee.Amount = []sacco.Coin{
+			{
+				Denom:  fp.Denom,
+				Amount: strconv.FormatUint(gasPriceAmount(fp.GasPrice, gas), 10),
+			},
+		}
+	}
+
+	return fee
+}
+
+// gasPriceAmount returns the amount needed to pay gas units at gasPrice, rounded up.
+func gasPriceAmount(gasPrice types.Dec, gas uint64) uint64 {
+	return uint64(gasPrice.MulInt64(int64(gas)).Ceil().TruncateInt64())
+}
+
+// Fee represents the fee and gas limit attached to a transaction.
+type Fee struct {
+	// Amount is the fee amount, expressed in Denom.
+	Amount uint64
+
+	// Denom is the denomination in which the fee is paid.
+	Denom string
+
+	// Gas is the gas limit for the whole transaction.
+	Gas uint64
+}
+
+// validate checks that f can be attached to a transaction.
+func (f Fee) validate() error {
+	if f.Denom == "" {
+		return errors.New("missing fee denom")
+	}
+
+	if f.Gas == 0 {
+		return errors.New("gas limit cannot be zero")
+	}
+
+	return nil
+}
+
+// asSaccoFee returns f as a sacco Fee.
+func (f Fee) asSaccoFee() sacco.Fee {
+	return sacco.Fee{
+		Amount: []sacco.Coin{
+			{
+				Denom:  f.Denom,
+				Amount: strconv.FormatUint(f.Amount, 10),
+			},
+		},
+		Gas: strconv.FormatUint(f.Gas, 10),
+	}
+}
+
+// SendTransactionWithFee works like SendTransaction, but attaches fee to the transaction instead of computing it
+// with the configured FeePolicy.
+func (sdk *SDK) SendTransactionWithFee(fee Fee, rawMsgs ...interface{}) (string, error) {
+	if err := fee.validate(); err != nil {
+		return "", fmt.Errorf("%w, %s", ErrInvalidFee, err.Error())
+	}
+
+	txp, err := sdk.genTx(rawMsgs...)
+	if err != nil {
+		return "", err
+	}
+
+	txp.Fee = fee.asSaccoFee()
+
+	return sdk.signAndBroadcast(txp)
+}
@@ -0,0 +1,307 @@
+package commercio
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeePolicy_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  FeePolicy
+		wantErr bool
+	}{
+		{
+			"default fee policy must not error",
+			DefaultFeePolicy,
+			false,
+		},
+		{
+			"missing denom",
+			FeePolicy{},
+			true,
+		},
+		{
+			"missing gas",
+			FeePolicy{
+				Denom: "ucommercio",
+			},
+			true,
+		},
+		{
+			"missing fee mode",
+			FeePolicy{
+				Denom: "ucommercio",
+				Gas:   200000,
+			},
+			true,
+		},
+		{
+			"only gas per message",
+			FeePolicy{
+				Mode:      FeeModeFixed,
+				Denom:     "ucommercio",
+				GasPerMsg: 100000,
+			},
+			false,
+		},
+		{
+			"max gas lower than gas per message",
+			FeePolicy{
+				Mode:      FeeModeFixed,
+				Denom:     "ucommercio",
+				GasPerMsg: 200000,
+				MaxGas:    100000,
+			},
+			true,
+		},
+		{
+			"simulation without gas adjustment",
+			FeePolicy{
+				Mode:     FeeModeFixed,
+				Denom:    "ucommercio",
+				Gas:      200000,
+				Simulate: true,
+			},
+			true,
+		},
+		{
+			"table mode without table",
+			FeePolicy{
+				Mode:  FeeModeTable,
+				Denom: "ucommercio",
+				Gas:   200000,
+			},
+			true,
+		},
+		{
+			"table mode with table",
+			FeePolicy{
+				Mode:       FeeModeTable,
+				Denom:      "ucommercio",
+				Gas:        200000,
+				MsgAmounts: map[string]uint64{"cosmos-sdk/MsgSend": 1000},
+			},
+			false,
+		},
+		{
+			"gas price mode without gas price",
+			FeePolicy{
+				Mode:  FeeModeGasPrice,
+				Denom: "ucommercio",
+				Gas:   200000,
+			},
+			true,
+		},
+		{
+			"gas price mode with zero gas price",
+			FeePolicy{
+				Mode:     FeeModeGasPrice,
+				Denom:    "ucommercio",
+				Gas:      200000,
+				GasPrice: types.ZeroDec(),
+			},
+			true,
+		},
+		{
+			"gas price mode with gas price",
+			FeePolicy{
+				Mode:     FeeModeGasPrice,
+				Denom:    "ucommercio",
+				Gas:      200000,
+				GasPrice: types.NewDecWithPrec(25, 3),
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr {
+				require.Error(t, tt.policy.validate())
+				return
+			}
+
+			require.NoError(t, tt.policy.validate())
+		})
+	}
+}
+
+func TestFeePolicy_fee(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   FeePolicy
+		msgTypes []string
+		want     sacco.Fee
+	}{
+		{
+			"default fee policy",
+			DefaultFeePolicy,
+			[]string{"cosmos-sdk/MsgSend", "cosmos-sdk/MsgSend"},
+			sacco.Fee{
+				Amount: []sacco.Coin{{Denom: "ucommercio", Amount: "20000"}},
+				Gas:    "200000",
+			},
+		},
+		{
+			"table mode, with a message type not in the table",
+			FeePolicy{
+				Mode:       FeeModeTable,
+				Denom:      "ucommercio",
+				Amount:     10000,
+				Gas:        200000,
+				GasPerMsg:  100000,
+				MsgAmounts: map[string]uint64{"cosmos-sdk/MsgSend": 1000},
+			},
+			[]string{"cosmos-sdk/MsgSend", "commercio/MsgShareDocument"},
+			sacco.Fee{
+				Amount: []sacco.Coin{{Denom: "ucommercio", Amount: "11000"}},
+				Gas:    "200000",
+			},
+		},
+		{
+			"gas price mode, amount gets rounded up",
+			FeePolicy{
+				Mode:     FeeModeGasPrice,
+				Denom:    "ucommercio",
+				Gas:      100001,
+				GasPrice: types.NewDecWithPrec(25, 3),
+			},
+			[]string{"cosmos-sdk/MsgSend"},
+			sacco.Fee{
+				Amount: []sacco.Coin{{Denom: "ucommercio", Amount: "2501"}},
+				Gas:    "100001",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.policy.fee(tt.msgTypes))
+		})
+	}
+}
+
+func TestFeePolicy_withGas(t *testing.T) {
+	gasPricePolicy := FeePolicy{
+		Mode:     FeeModeGasPrice,
+		Denom:    "ucommercio",
+		Gas:      100000,
+		GasPrice: types.NewDecWithPrec(25, 3),
+	}
+
+	tests := []struct {
+		name   string
+		policy FeePolicy
+		gas    uint64
+		want   sacco.Fee
+	}{
+		{
+			"fixed mode only changes gas",
+			DefaultFeePolicy,
+			1000,
+			sacco.Fee{
+				Amount: []sacco.Coin{{Denom: "ucommercio", Amount: "10000"}},
+				Gas:    "1000",
+			},
+		},
+		{
+			"gas price mode recomputes amount",
+			gasPricePolicy,
+			1000,
+			sacco.Fee{
+				Amount: []sacco.Coin{{Denom: "ucommercio", Amount: "25"}},
+				Gas:    "1000",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.policy.withGas(tt.policy.fee([]string{"cosmos-sdk/MsgSend"}), tt.gas))
+		})
+	}
+}
+
+func TestFeePolicy_batchSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		maxGas uint64
+		msgGas uint64
+		want   int
+	}{
+		{"no max gas", 0, 100000, 0},
+		{"unknown message gas", 1000000, 0, 0},
+		{"many messages per transaction", 1000000, 300000, 3},
+		{"message needing more than max gas", 100000, 300000, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, FeePolicy{MaxGas: tt.maxGas}.batchSize(tt.msgGas))
+		})
+	}
+}
+
+func TestSDK_SendTransactionWithFee(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		fee     Fee
+		msgs    []interface{}
+		wantErr bool
+	}{
+		{
+			"invalid fee",
+			Fee{},
+			[]interface{}{MsgSend{}},
+			true,
+		},
+		{
+			"trigger error in genTx",
+			Fee{Amount: 1, Denom: "ucommercio", Gas: 1},
+			nil,
+			true,
+		},
+		{
+			"fee gets attached to the transaction",
+			Fee{Amount: 42, Denom: "ucommercio", Gas: 4242},
+			[]interface{}{MsgSend{}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			var sentBody sacco.TxBody
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/node_info", httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.NodeInfo{}))
+			httpmock.RegisterResponder(http.MethodPost, "http://localhost:1317/txs", func(req *http.Request) (*http.Response, error) {
+				if err := json.NewDecoder(req.Body).Decode(&sentBody); err != nil {
+					return nil, err
+				}
+
+				return httpmock.NewJsonResponse(http.StatusOK, sacco.TxResponse{TxHash: "ok!"})
+			})
+			httpmock.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile("http://localhost:1317/auth/accounts/(.+)"), httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.AccountData{Result: sacco.AccountDataResult{Value: sacco.AccountDataValue{Address: "address"}}}))
+
+			res, err := sdk.SendTransactionWithFee(tt.fee, tt.msgs...)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, "", res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "ok!", res)
+			require.Equal(t, tt.fee.asSaccoFee(), sentBody.Tx.Fee)
+		})
+	}
+}
@@ -9,8 +9,12 @@ require (
 	github.com/commercionetwork/sacco.go v0.2.2
 	github.com/cosmos/cosmos-sdk v0.38.3
 	github.com/cosmos/go-bip39 v0.0.0-20180819234021-555e2067c45d
+	github.com/gorilla/websocket v1.4.1
 	github.com/jarcoal/httpmock v1.0.5
 	github.com/satori/go.uuid v1.2.0
 	github.com/stretchr/testify v1.5.1
+	github.com/tendermint/go-amino v0.15.1
+	github.com/tendermint/tendermint v0.33.3
 	github.com/valyala/fastjson v1.5.1
+	golang.org/x/crypto v0.0.0-20191206172530-e9b2fee46413
 )
@@ -1,12 +1,7 @@
 package commercio
 
 import (
-	"bytes"
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
 	"crypto/rsa"
-	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
@@ -15,19 +10,23 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"strconv"
+	"strings"
 	"time"
 
-	"crypto"
-
 	"github.com/btcsuite/btcd/btcec"
-	"github.com/btcsuite/btcutil/hdkeychain"
 	id "github.com/commercionetwork/commercionetwork/x/id/types"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/types"
 	uuid "github.com/satori/go.uuid"
-	"github.com/valyala/fastjson"
+)
+
+// Public key types accepted in a DidDocument.
+const (
+	KeyTypeRsaVerification = id.KeyTypeRsaVerification
+	KeyTypeRsaSignature    = id.KeyTypeRsaSignature
+	KeyTypeSecp256k1       = id.KeyTypeSecp256k1
+	KeyTypeEd25519         = id.KeyTypeEd25519
 )
 
 // BuildDidDocument creates a DidDocument for the account associated to sdk, given its publick key, signature and
@@ -37,7 +36,7 @@ func (sdk *SDK) BuildDidDocument(pubKeyString string, signatureKey, verification
 		return DidDocument{}, fmt.Errorf("%w, %s", w, ext.Error())
 	}
 
-	uAddr := sdk.wallet.Address
+	uAddr := sdk.signer.Address()
 
 	_, err := types.GetPubKeyFromBech32(types.Bech32PubKeyTypeAccPub, pubKeyString)
 	if err != nil {
@@ -59,60 +58,151 @@ func (sdk *SDK) BuildDidDocument(pubKeyString string, signatureKey, verification
 		return e(ErrInvalidVerificationKey, err)
 	}
 
-	didDocument := DidDocument{
-		Context: "https://www.w3.org/ns/did/v1",
-		ID:      wacc,
-		PubKeys: id.PubKeys{
-			id.PubKey{
-				ID:           uAddr + "#keys-1",
-				Type:         "RsaVerificationKey2018",
-				Controller:   wacc,
-				PublicKeyPem: vk,
-			},
-			id.PubKey{
-				ID:           uAddr + "#keys-2",
-				Type:         "RsaSignatureKey2018",
-				Controller:   wacc,
-				PublicKeyPem: sk,
-			},
+	return sdk.buildDidDocument(pubKeyString, wacc, id.PubKeys{
+		id.PubKey{
+			ID:           uAddr + "#keys-1",
+			Type:         KeyTypeRsaVerification,
+			Controller:   wacc,
+			PublicKeyPem: vk,
+		},
+		id.PubKey{
+			ID:           uAddr + "#keys-2",
+			Type:         KeyTypeRsaSignature,
+			Controller:   wacc,
+			PublicKeyPem: sk,
 		},
+	}, nil)
+}
+
+// DidDocumentParams are parameters used by BuildDidDocumentWithParams.
+type DidDocumentParams struct {
+	// PubKeys are the public keys listed in the DidDocument, usually created by NewDidPubKey.
+	// They must contain a KeyTypeRsaVerification key with index 1 and a KeyTypeRsaSignature key with index 2,
+	// keys with no Controller are controlled by the account associated to the SDK.
+	PubKeys []PubKey
+
+	// Services are the service endpoints listed in the DidDocument, they are optional.
+	Services []Service
+}
+
+// NewDidPubKey creates the public key with the given index and keyType, to be listed in the DidDocument of the
+// account associated to sdk.
+// RSA keys are read from key as PKIX public keys, other key types are read as they are.
+func (sdk *SDK) NewDidPubKey(index uint, keyType string, key io.Reader) (PubKey, error) {
+	e := func(ext error) (PubKey, error) {
+		return PubKey{}, fmt.Errorf("%w, %s", ErrInvalidDidDocument, ext.Error())
 	}
 
-	oProof := Proof{
-		Type:               "EcdsaSecp256k1VerificationKey2019",
-		Created:            time.Now(),
-		ProofPurpose:       "authentication",
-		Controller:         uAddr,
-		VerificationMethod: pubKeyString,
+	wacc, err := types.AccAddressFromBech32(sdk.signer.Address())
+	if err != nil {
+		return PubKey{}, fmt.Errorf("%w, %s", ErrInvalidAddress, err.Error())
+	}
+
+	var value string
+
+	switch keyType {
+	case KeyTypeRsaVerification, KeyTypeRsaSignature:
+		value, _, err = readKey(key, typePublicKey)
+	case KeyTypeSecp256k1, KeyTypeEd25519:
+		var raw []byte
+		raw, err = ioutil.ReadAll(key)
+		value = string(raw)
+	default:
+		return e(fmt.Errorf("key type %s not supported", keyType))
 	}
 
-	data, err := json.Marshal(didDocument)
 	if err != nil {
-		return e(ErrProofCreation, err)
+		return e(fmt.Errorf("key #%d: %w", index, err))
 	}
 
-	wex, err := sdk.wallet.ExportWithPrivateKey()
+	return PubKey{
+		ID:           fmt.Sprintf("%s#keys-%d", wacc, index),
+		Type:         keyType,
+		Controller:   wacc,
+		PublicKeyPem: value,
+	}, nil
+}
+
+// BuildDidDocumentWithParams creates a DidDocument for the account associated to sdk, listing the public keys and
+// the services contained in params.
+// The DidDocument proof is verified by the SDK public key, and the DidDocument is validated as commercio.network
+// would do before returning it.
+func (sdk *SDK) BuildDidDocumentWithParams(params DidDocumentParams) (DidDocument, error) {
+	e := func(ext error) (DidDocument, error) {
+		return DidDocument{}, fmt.Errorf("%w, %s", ErrInvalidDidDocument, ext.Error())
+	}
+
+	wacc, err := types.AccAddressFromBech32(sdk.signer.Address())
 	if err != nil {
-		return e(ErrProofCreation, err)
+		return DidDocument{}, fmt.Errorf("%w, %s", ErrInvalidAddress, err.Error())
 	}
 
-	kc, err := hdkeychain.NewKeyFromString(fastjson.GetString([]byte(wex), "private_key"))
+	pubKeys := make(id.PubKeys, len(params.PubKeys))
+	seen := make(map[string]bool, len(params.PubKeys))
+	for i, pk := range params.PubKeys {
+		if pk.Controller == nil {
+			pk.Controller = wacc
+		}
+
+		if seen[pk.ID] {
+			return e(fmt.Errorf("duplicate key %s", pk.ID))
+		}
+		seen[pk.ID] = true
+
+		pubKeys[i] = id.PubKey(pk)
+	}
+
+	var services id.Services
+	for _, s := range params.Services {
+		services = append(services, id.Service(s))
+	}
+
+	didDocument, err := sdk.buildDidDocument(sdk.PublicKey, wacc, pubKeys, services)
 	if err != nil {
-		return e(ErrProofCreation, err)
+		return DidDocument{}, err
 	}
 
-	ec, err := kc.ECPrivKey()
+	if err := id.DidDocument(didDocument).Validate(); err != nil {
+		return e(err)
+	}
+
+	return didDocument, nil
+}
+
+// buildDidDocument creates the DidDocument of wacc listing pubKeys and services, and signs it with the SDK
+// private key.
+// pubKeyString is the bech32-encoded public key used to verify the DidDocument proof.
+func (sdk *SDK) buildDidDocument(pubKeyString string, wacc types.AccAddress, pubKeys id.PubKeys, services id.Services) (DidDocument, error) {
+	e := func(w error, ext error) (DidDocument, error) {
+		return DidDocument{}, fmt.Errorf("%w, %s", w, ext.Error())
+	}
+
+	didDocument := DidDocument{
+		Context: id.ContextDidV1,
+		ID:      wacc,
+		PubKeys: pubKeys,
+		Service: services,
+	}
+
+	oProof := Proof{
+		Type:               "EcdsaSecp256k1VerificationKey2019",
+		Created:            time.Now(),
+		ProofPurpose:       "authentication",
+		Controller:         wacc.String(),
+		VerificationMethod: pubKeyString,
+	}
+
+	data, err := json.Marshal(didDocument)
 	if err != nil {
 		return e(ErrProofCreation, err)
 	}
 
-	sum := sha256.Sum256(data[:])
-	signature, err := ec.Sign(sum[:])
+	signature, err := signWith(sdk.signer, data)
 	if err != nil {
 		return e(ErrProofCreation, err)
 	}
 
-	oProof.SignatureValue = base64.StdEncoding.EncodeToString(serializeSig(signature))
+	oProof.SignatureValue = base64.StdEncoding.EncodeToString(signature)
 
 	ddProof := id.Proof(oProof)
 	didDocument.Proof = &ddProof
@@ -126,6 +216,7 @@ type PowerUpParams struct {
 	PubKey string
 
 	// TumblerKey is the tumbler RSA PKIX public key.
+	// When nil, the key returned by SDK.TumblerKey is used.
 	TumblerKey io.Reader
 
 	// SignatureKey is the user RSA PKCS8 private key, used to build the message proof.
@@ -147,10 +238,6 @@ func (p PowerUpParams) validate() error {
 		return errors.New("missing pubkey")
 	}
 
-	if p.TumblerKey == nil {
-		return errors.New("tumbler key reader must not be nil")
-	}
-
 	if p.SignatureKey == nil {
 		return errors.New("verification key reader must not be nil")
 	}
@@ -184,7 +271,7 @@ func (sdk *SDK) BuildPowerupRequest(params PowerUpParams) (MsgRequestDidPowerUp,
 		return e(ErrInvalidPowerupParams, err)
 	}
 
-	uAddr := sdk.wallet.Address
+	uAddr := sdk.signer.Address()
 
 	_, err := types.GetPubKeyFromBech32(types.Bech32PubKeyTypeAccPub, params.PubKey)
 	if err != nil {
@@ -210,11 +297,10 @@ func (sdk *SDK) BuildPowerupRequest(params PowerUpParams) (MsgRequestDidPowerUp,
 	proof := requestPowerupProof{
 		SenderDid:   wacc,
 		PairwiseDid: params.PairwiseAddress,
-		Timestamp:   time.Now().Unix(),
+		Timestamp:   timeProvider().Unix(),
 	}
 
 	sigPayload := proof.SenderDid.String() + proof.PairwiseDid.String() + strconv.FormatInt(proof.Timestamp, 10)
-	payloadHash := sha256.Sum256([]byte(sigPayload))
 
 	_, rawKey, err := readKey(params.SignatureKey, typePrivateKey)
 	if err != nil {
@@ -228,10 +314,10 @@ func (sdk *SDK) BuildPowerupRequest(params PowerUpParams) (MsgRequestDidPowerUp,
 		panic(fmt.Errorf("readKey parsed the private SignatureKey, but somehow it's not a *rsa.PrivateKey"))
 	}
 
-	// sign the proof hash
-	signature, err := rsa.SignPKCS1v15(rand.Reader, privKey, crypto.SHA256, payloadHash[:])
+	// sign the proof payload hash, with the scheme selected by the crypto profile
+	signature, err := sdk.config.CryptoProfile.sign(privKey, []byte(sigPayload))
 	if err != nil {
-		log.Fatal(err)
+		return e(ErrProofCreation, err)
 	}
 
 	// encode in base64
@@ -244,7 +330,17 @@ func (sdk *SDK) BuildPowerupRequest(params PowerUpParams) (MsgRequestDidPowerUp,
 	/*
 		proof now contains the blob we will encrypt with the tumbler public key
 	*/
-	_, tumblerRawKey, err := readKey(params.TumblerKey, typePublicKey)
+	tumblerKeyReader := params.TumblerKey
+	if tumblerKeyReader == nil {
+		tk, err := sdk.TumblerKey()
+		if err != nil {
+			return e(ErrInvalidTumblerKey, err)
+		}
+
+		tumblerKeyReader = strings.NewReader(tk)
+	}
+
+	_, tumblerRawKey, err := readKey(tumblerKeyReader, typePublicKey)
 	if err != nil {
 		return e(ErrInvalidTumblerKey, err)
 	}
@@ -257,38 +353,22 @@ func (sdk *SDK) BuildPowerupRequest(params PowerUpParams) (MsgRequestDidPowerUp,
 	}
 
 	// AES-GCM encryption of proofJSON
-	nonce := make([]byte, 12)
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return e(ErrNotEnoughEntropy, fmt.Errorf("cannot generate AES nonce, %w", err))
-	}
-
-	key := make([]byte, 32)
-	if _, err := io.ReadFull(rand.Reader, key); err != nil {
-		return e(ErrNotEnoughEntropy, fmt.Errorf("cannot generate AES encryption key, %w", err))
-	}
-
-	block, err := aes.NewCipher(key)
+	key, err := newAESKey()
 	if err != nil {
-		return e(ErrEncryptionFailure, err)
+		return MsgRequestDidPowerUp{}, err
 	}
 
-	aesgcm, err := cipher.NewGCM(block)
+	ciphertext, err := aesEncrypt(key, proofJSON)
 	if err != nil {
-		return e(ErrEncryptionFailure, err)
+		return MsgRequestDidPowerUp{}, err
 	}
 
-	ciphertext := aesgcm.Seal(nil, nonce, proofJSON, nil)
-
-	finalc := bytes.Buffer{}
-	finalc.Write(nonce)
-	finalc.Write(ciphertext)
-
 	// convert it in base64
-	epb64 := base64.StdEncoding.EncodeToString(finalc.Bytes())
+	epb64 := base64.StdEncoding.EncodeToString(ciphertext)
 
 	request.Proof = epb64
 
-	encryptedKey, err := rsa.EncryptPKCS1v15(rand.Reader, tumblerKey, key)
+	encryptedKey, err := sdk.config.CryptoProfile.encrypt(tumblerKey, key)
 	if err != nil {
 		return e(ErrEncryptionFailure, err)
 	}
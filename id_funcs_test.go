@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	id "github.com/commercionetwork/commercionetwork/x/id/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -303,3 +304,127 @@ func TestSDK_BuildDidDocument(t *testing.T) {
 		})
 	}
 }
+
+func TestSDK_BuildDidDocumentWithParams(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	_, rsaKey := testRSAKeypair(t)
+
+	newKey := func(index uint, keyType string, key string) PubKey {
+		pk, err := sdk.NewDidPubKey(index, keyType, strings.NewReader(key))
+		require.NoError(t, err)
+		return pk
+	}
+
+	verificationKey := newKey(1, KeyTypeRsaVerification, rsaKey)
+	signatureKey := newKey(2, KeyTypeRsaSignature, rsaKey)
+	extraKey := newKey(3, KeyTypeSecp256k1, sdk.PublicKey)
+
+	service := Service{ID: "delivery", Type: "DocumentDelivery", ServiceEndpoint: "https://example.com/documents"}
+
+	tests := []struct {
+		name    string
+		params  DidDocumentParams
+		wantErr bool
+	}{
+		{
+			"keys and services",
+			DidDocumentParams{
+				PubKeys:  []PubKey{verificationKey, signatureKey, extraKey},
+				Services: []Service{service},
+			},
+			false,
+		},
+		{
+			"keys only",
+			DidDocumentParams{
+				PubKeys: []PubKey{verificationKey, signatureKey},
+			},
+			false,
+		},
+		{
+			"key with no controller",
+			DidDocumentParams{
+				PubKeys: []PubKey{verificationKey, signatureKey, {ID: extraKey.ID, Type: extraKey.Type, PublicKeyPem: extraKey.PublicKeyPem}},
+			},
+			false,
+		},
+		{
+			"missing signature key",
+			DidDocumentParams{
+				PubKeys: []PubKey{verificationKey, extraKey},
+			},
+			true,
+		},
+		{
+			"duplicate key",
+			DidDocumentParams{
+				PubKeys: []PubKey{verificationKey, signatureKey, signatureKey},
+			},
+			true,
+		},
+		{
+			"invalid service",
+			DidDocumentParams{
+				PubKeys:  []PubKey{verificationKey, signatureKey},
+				Services: []Service{{ID: "delivery"}},
+			},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := sdk.BuildDidDocumentWithParams(tt.params)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrInvalidDidDocument))
+				require.Equal(t, DidDocument{}, d)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, d.PubKeys, len(tt.params.PubKeys))
+			require.Len(t, d.Service, len(tt.params.Services))
+			require.Equal(t, sdk.PublicKey, d.Proof.VerificationMethod)
+			require.NoError(t, id.DidDocument(d).VerifyProof())
+		})
+	}
+}
+
+func TestSDK_NewDidPubKey(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	_, rsaKey := testRSAKeypair(t)
+
+	tests := []struct {
+		name    string
+		keyType string
+		key     io.Reader
+		wantErr bool
+	}{
+		{"rsa key", KeyTypeRsaVerification, strings.NewReader(rsaKey), false},
+		{"secp256k1 key", KeyTypeSecp256k1, strings.NewReader(sdk.PublicKey), false},
+		{"invalid rsa key", KeyTypeRsaSignature, strings.NewReader("not a key"), true},
+		{"unsupported key type", "RsaKey", strings.NewReader(rsaKey), true},
+		{"failing reader", KeyTypeEd25519, failingReader{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pk, err := sdk.NewDidPubKey(4, tt.keyType, tt.key)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, PubKey{}, pk)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, sdk.Address+"#keys-4", pk.ID)
+			require.Equal(t, tt.keyType, pk.Type)
+			require.Equal(t, sdk.Address, pk.Controller.String())
+		})
+	}
+}
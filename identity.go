@@ -0,0 +1,209 @@
+package commercio
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// IdentityOptions are options used by CreateIdentity and UpdateIdentity.
+type IdentityOptions struct {
+	// VerificationKey is the PEM-encoded RSA PKIX public key listed as #keys-1 in the DidDocument.
+	// When nil, a new RSA keypair is generated.
+	VerificationKey io.Reader
+
+	// SignatureKey is the PEM-encoded RSA PKIX public key listed as #keys-2 in the DidDocument.
+	// When nil, a new RSA keypair is generated.
+	SignatureKey io.Reader
+
+	// AdditionalKeys are listed in the DidDocument after the verification and signature keys.
+	AdditionalKeys []PubKey
+
+	// Services are the service endpoints listed in the DidDocument.
+	Services []Service
+}
+
+// IdentityKeys holds the PEM-encoded RSA keypairs listed in a DidDocument.
+// Private keys are set only for the keypairs generated by the SDK, and must be stored by the caller since they
+// cannot be recovered.
+type IdentityKeys struct {
+	VerificationPublicKey  string
+	VerificationPrivateKey string
+	SignaturePublicKey     string
+	SignaturePrivateKey    string
+}
+
+// IdentityResult is the outcome of CreateIdentity and UpdateIdentity.
+type IdentityResult struct {
+	// Keys are the RSA keys listed in DidDocument.
+	Keys IdentityKeys
+
+	// DidDocument is the DidDocument set for the account associated to the SDK.
+	DidDocument DidDocument
+
+	// TxHash is the hash of the transaction containing the MsgSetIdentity.
+	TxHash string
+}
+
+// CreateIdentity builds the DidDocument of the account associated to sdk from opts, generating the RSA keypairs
+// not provided, then sets it on chain with a MsgSetIdentity.
+func (sdk *SDK) CreateIdentity(opts IdentityOptions) (IdentityResult, error) {
+	return sdk.setIdentity(opts, nil)
+}
+
+// UpdateIdentity replaces the verification and signature keys listed in the DidDocument of the account associated
+// to sdk, generating the RSA keypairs not provided, then sets it on chain with a MsgSetIdentity.
+// Additional keys and services already listed in the DidDocument are preserved, unless opts contains ones with the
+// same ID, which replace them.
+func (sdk *SDK) UpdateIdentity(opts IdentityOptions) (IdentityResult, error) {
+	address, err := types.AccAddressFromBech32(sdk.Address)
+	if err != nil {
+		return IdentityResult{}, fmt.Errorf("%w, %s", ErrInvalidAddress, err.Error())
+	}
+
+	current, err := sdk.Identity(address)
+	if err != nil {
+		return IdentityResult{}, err
+	}
+
+	return sdk.setIdentity(opts, &current)
+}
+
+// setIdentity builds the DidDocument described by opts, merged with current if not nil, then sends it in a
+// MsgSetIdentity.
+func (sdk *SDK) setIdentity(opts IdentityOptions, current *DidDocument) (IdentityResult, error) {
+	var keys IdentityKeys
+
+	bits := sdk.config.CryptoProfile.withDefaults().RSAKeyBits
+
+	verificationKey, err := identityKey(opts.VerificationKey, bits, &keys.VerificationPublicKey, &keys.VerificationPrivateKey)
+	if err != nil {
+		return IdentityResult{}, err
+	}
+
+	signatureKey, err := identityKey(opts.SignatureKey, bits, &keys.SignaturePublicKey, &keys.SignaturePrivateKey)
+	if err != nil {
+		return IdentityResult{}, err
+	}
+
+	vk, err := sdk.NewDidPubKey(1, KeyTypeRsaVerification, strings.NewReader(verificationKey))
+	if err != nil {
+		return IdentityResult{}, fmt.Errorf("%w, %s", ErrInvalidVerificationKey, err.Error())
+	}
+
+	sk, err := sdk.NewDidPubKey(2, KeyTypeRsaSignature, strings.NewReader(signatureKey))
+	if err != nil {
+		return IdentityResult{}, fmt.Errorf("%w, %s", ErrInvalidSignatureKey, err.Error())
+	}
+
+	additionalKeys := opts.AdditionalKeys
+	services := opts.Services
+
+	if current != nil {
+		additionalKeys = mergePubKeys(currentAdditionalKeys(*current, vk.ID, sk.ID), opts.AdditionalKeys)
+		services = mergeServices(Services(current.Service), opts.Services)
+	}
+
+	didDocument, err := sdk.BuildDidDocumentWithParams(DidDocumentParams{
+		PubKeys:  append([]PubKey{vk, sk}, additionalKeys...),
+		Services: services,
+	})
+	if err != nil {
+		return IdentityResult{}, err
+	}
+
+	txHash, err := sdk.SendTransaction(MsgSetIdentity(didDocument))
+	if err != nil {
+		return IdentityResult{}, err
+	}
+
+	return IdentityResult{
+		Keys:        keys,
+		DidDocument: didDocument,
+		TxHash:      txHash,
+	}, nil
+}
+
+// identityKey reads a PEM-encoded RSA public key from r into public, or generates a new keypair of the given size
+// when r is nil and stores it into public and private.
+// It returns the public key.
+func identityKey(r io.Reader, bits int, public, private *string) (string, error) {
+	if r == nil {
+		priv, pub, err := NewRSAKeypairWithBits(bits)
+		if err != nil {
+			return "", fmt.Errorf("%w, %s", ErrKeyGeneration, err.Error())
+		}
+
+		*public, *private = pub, priv
+		return pub, nil
+	}
+
+	pub, _, err := readKey(r, typePublicKey)
+	if err != nil {
+		return "", fmt.Errorf("%w, %s", ErrInvalidDidDocument, err.Error())
+	}
+
+	*public = pub
+	return pub, nil
+}
+
+// currentAdditionalKeys returns the keys listed in doc, except the ones identified by replaced.
+func currentAdditionalKeys(doc DidDocument, replaced ...string) []PubKey {
+	var keys []PubKey
+	for _, key := range doc.PubKeys {
+		if !containsString(replaced, key.ID) {
+			keys = append(keys, PubKey(key))
+		}
+	}
+
+	return keys
+}
+
+// mergePubKeys returns current with the keys having the same ID of the ones in updated replaced, followed by the
+// remaining keys in updated.
+func mergePubKeys(current, updated []PubKey) []PubKey {
+	merged := append([]PubKey{}, current...)
+
+	for _, key := range updated {
+		replaced := false
+		for i := range merged {
+			if merged[i].ID == key.ID {
+				merged[i] = key
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			merged = append(merged, key)
+		}
+	}
+
+	return merged
+}
+
+// mergeServices returns current with the services having the same ID of the ones in updated replaced, followed by
+// the remaining services in updated.
+func mergeServices(current Services, updated []Service) []Service {
+	var merged []Service
+	for _, s := range current {
+		merged = append(merged, Service(s))
+	}
+
+	for _, s := range updated {
+		replaced := false
+		for i := range merged {
+			if merged[i].ID == s.ID {
+				merged[i] = s
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			merged = append(merged, s)
+		}
+	}
+
+	return merged
+}
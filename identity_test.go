@@ -0,0 +1,162 @@
+package commercio
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDK_CreateIdentity(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	_, rsaKey := testRSAKeypair(t)
+
+	service := Service{ID: "delivery", Type: "DocumentDelivery", ServiceEndpoint: "https://example.com/documents"}
+
+	tests := []struct {
+		name          string
+		opts          IdentityOptions
+		wantGenerated bool
+		wantErr       error
+	}{
+		{
+			"generated keys",
+			IdentityOptions{Services: []Service{service}},
+			true,
+			nil,
+		},
+		{
+			"provided keys",
+			IdentityOptions{
+				VerificationKey: strings.NewReader(rsaKey),
+				SignatureKey:    strings.NewReader(rsaKey),
+			},
+			false,
+			nil,
+		},
+		{
+			"invalid signature key",
+			IdentityOptions{SignatureKey: strings.NewReader("not a key")},
+			false,
+			ErrInvalidDidDocument,
+		},
+		{
+			"invalid service",
+			IdentityOptions{Services: []Service{{ID: "delivery"}}},
+			false,
+			ErrInvalidDidDocument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			registerAccountResponders(sdk, 0, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "ok!"}))
+
+			res, err := sdk.CreateIdentity(tt.opts)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.wantErr))
+				require.Equal(t, IdentityResult{}, res)
+				require.Equal(t, 0, httpmock.GetCallCountInfo()["POST http://localhost:1317/txs"])
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "ok!", res.TxHash)
+			require.NoError(t, VerifyDidDocument(res.DidDocument))
+			require.Len(t, res.DidDocument.Service, len(tt.opts.Services))
+			require.Equal(t, res.Keys.VerificationPublicKey, res.DidDocument.PubKeys[0].PublicKeyPem)
+			require.Equal(t, res.Keys.SignaturePublicKey, res.DidDocument.PubKeys[1].PublicKeyPem)
+
+			if tt.wantGenerated {
+				require.NotEmpty(t, res.Keys.VerificationPrivateKey)
+				require.NotEmpty(t, res.Keys.SignaturePrivateKey)
+				require.NotEqual(t, res.Keys.VerificationPublicKey, res.Keys.SignaturePublicKey)
+			} else {
+				require.Empty(t, res.Keys.VerificationPrivateKey)
+				require.Empty(t, res.Keys.SignaturePrivateKey)
+			}
+		})
+	}
+}
+
+func TestSDK_UpdateIdentity(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	_, oldKey := testRSAKeypair(t)
+	_, newKey := testRSAKeypair(t)
+
+	newPubKey := func(index uint, keyType, key string) PubKey {
+		pk, err := sdk.NewDidPubKey(index, keyType, strings.NewReader(key))
+		require.NoError(t, err)
+		return pk
+	}
+
+	current, err := sdk.BuildDidDocumentWithParams(DidDocumentParams{
+		PubKeys: []PubKey{
+			newPubKey(1, KeyTypeRsaVerification, oldKey),
+			newPubKey(2, KeyTypeRsaSignature, oldKey),
+			newPubKey(3, KeyTypeSecp256k1, sdk.PublicKey),
+		},
+		Services: []Service{
+			{ID: "delivery", Type: "DocumentDelivery", ServiceEndpoint: "https://example.com/documents"},
+			{ID: "profile", Type: "Profile", ServiceEndpoint: "https://example.com/profile"},
+		},
+	})
+	require.NoError(t, err)
+
+	identity, err := sdk.codec.MarshalJSON(identityResponse{Owner: current.ID, DidDocument: &current})
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerAccountResponders(sdk, 0, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "ok!"}))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/identities/"+sdk.Address, lcdResponder(string(identity)))
+
+	res, err := sdk.UpdateIdentity(IdentityOptions{
+		SignatureKey: strings.NewReader(newKey),
+		Services: []Service{
+			{ID: "profile", Type: "Profile", ServiceEndpoint: "https://example.com/new-profile"},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, VerifyDidDocument(res.DidDocument))
+
+	// rotated keys
+	require.NotEmpty(t, res.Keys.VerificationPrivateKey)
+	require.NotEqual(t, oldKey, res.DidDocument.PubKeys[0].PublicKeyPem)
+	require.Equal(t, newKey, res.DidDocument.PubKeys[1].PublicKeyPem)
+
+	// preserved key and services
+	require.Len(t, res.DidDocument.PubKeys, 3)
+	require.Equal(t, current.PubKeys[2], res.DidDocument.PubKeys[2])
+	require.Len(t, res.DidDocument.Service, 2)
+	require.Equal(t, current.Service[0], res.DidDocument.Service[0])
+	require.Equal(t, "https://example.com/new-profile", res.DidDocument.Service[1].ServiceEndpoint)
+}
+
+func TestSDK_UpdateIdentity_missingIdentity(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/identities/"+sdk.Address, lcdResponder(`{"owner":"`+sdk.Address+`"}`))
+
+	res, err := sdk.UpdateIdentity(IdentityOptions{})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrQuery))
+	require.Equal(t, IdentityResult{}, res)
+}
@@ -0,0 +1,260 @@
+package commercio
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keystoreVersion = 1
+	keystoreKDF     = "scrypt"
+	keystoreCipher  = "aes-256-gcm"
+
+	keystoreScryptR     = 8
+	keystoreScryptP     = 1
+	keystoreScryptDKLen = 32
+	keystoreSaltLen     = 32
+)
+
+// keystoreScryptN is the scrypt CPU/memory cost used to encrypt keystores, it has been defined for ease of testing.
+var keystoreScryptN = 1 << 18
+
+// keystoreFile is the JSON representation of an encrypted keystore.
+type keystoreFile struct {
+	Version int            `json:"version"`
+	Address string         `json:"address"`
+	Crypto  keystoreCrypto `json:"crypto"`
+}
+
+type keystoreCrypto struct {
+	Cipher     string            `json:"cipher"`
+	CipherText string            `json:"ciphertext"`
+	Nonce      string            `json:"nonce"`
+	KDF        string            `json:"kdf"`
+	KDFParams  keystoreKDFParams `json:"kdfparams"`
+}
+
+type keystoreKDFParams struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// NewSDKFromPrivateKey returns a new instance of SDK initialized by given hex-encoded secp256k1 private key and
+// config.
+func NewSDKFromPrivateKey(privateKey string, config SDKConfig) (*SDK, error) {
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	rawKey, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	key, err := parsePrivateKey(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	return NewSDKWithSigner(newPrivateKeySigner(key), config)
+}
+
+// NewSDKFromKeystore returns a new instance of SDK initialized by the private key contained in the keystore file
+// at path, encrypted with passphrase, and config.
+func NewSDKFromKeystore(path, passphrase string, config SDKConfig) (*SDK, error) {
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	key, err := decryptKeystore(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	return NewSDKWithSigner(newPrivateKeySigner(key), config)
+}
+
+// ExportKeystore writes to path a keystore file containing the SDK private key, encrypted with passphrase.
+// The resulting file can be read with NewSDKFromKeystore.
+// ExportKeystore works only if the SDK private key is held in memory, i.e. it hasn't been created with
+// NewSDKWithSigner.
+func (sdk *SDK) ExportKeystore(path, passphrase string) error {
+	e := func(ext error) error {
+		return fmt.Errorf("%w, %s", ErrKeystore, ext.Error())
+	}
+
+	pks, ok := sdk.signer.(privateKeySigner)
+	if !ok {
+		return e(errors.New("private key not available"))
+	}
+
+	data, err := encryptKeystore(pks, passphrase)
+	if err != nil {
+		return e(err)
+	}
+
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return e(err)
+	}
+
+	return nil
+}
+
+// parsePrivateKey parses rawKey as a secp256k1 private key.
+func parsePrivateKey(rawKey []byte) (*btcec.PrivateKey, error) {
+	if len(rawKey) != btcec.PrivKeyBytesLen {
+		return nil, fmt.Errorf("%w, must be %d bytes long", ErrInvalidPrivateKey, btcec.PrivKeyBytesLen)
+	}
+
+	d := new(big.Int).SetBytes(rawKey)
+	if d.Sign() == 0 || d.Cmp(btcec.S256().N) >= 0 {
+		return nil, fmt.Errorf("%w, out of the secp256k1 curve order", ErrInvalidPrivateKey)
+	}
+
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), rawKey)
+
+	return key, nil
+}
+
+// keystoreAEAD returns the AES-GCM cipher whose key is derived from passphrase with the given scrypt parameters.
+func keystoreAEAD(passphrase string, params keystoreKDFParams) (cipher.AEAD, error) {
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptKeystore returns the JSON keystore containing the private key of pks, encrypted with passphrase.
+func encryptKeystore(pks privateKeySigner, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("missing passphrase")
+	}
+
+	salt := make([]byte, keystoreSaltLen)
+	if n, err := entropyProvider(salt); err != nil || n != keystoreSaltLen {
+		return nil, ErrNotEnoughEntropy
+	}
+
+	params := keystoreKDFParams{
+		N:     keystoreScryptN,
+		R:     keystoreScryptR,
+		P:     keystoreScryptP,
+		DKLen: keystoreScryptDKLen,
+		Salt:  hex.EncodeToString(salt),
+	}
+
+	aead, err := keystoreAEAD(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if n, err := entropyProvider(nonce); err != nil || n != len(nonce) {
+		return nil, ErrNotEnoughEntropy
+	}
+
+	address := pks.Address()
+
+	// the address is authenticated along with the key, so that it cannot be tampered with
+	ciphertext := aead.Seal(nil, nonce, pks.key.Serialize(), []byte(address))
+
+	return json.Marshal(keystoreFile{
+		Version: keystoreVersion,
+		Address: address,
+		Crypto: keystoreCrypto{
+			Cipher:     keystoreCipher,
+			CipherText: hex.EncodeToString(ciphertext),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        keystoreKDF,
+			KDFParams:  params,
+		},
+	})
+}
+
+// decryptKeystore returns the private key contained in the JSON keystore data, encrypted with passphrase.
+func decryptKeystore(data []byte, passphrase string) (*btcec.PrivateKey, error) {
+	e := func(ext error) (*btcec.PrivateKey, error) {
+		return nil, fmt.Errorf("%w, %s", ErrKeystore, ext.Error())
+	}
+
+	var ks keystoreFile
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return e(err)
+	}
+
+	if ks.Version != keystoreVersion {
+		return e(fmt.Errorf("unsupported version %d", ks.Version))
+	}
+
+	if ks.Crypto.KDF != keystoreKDF || ks.Crypto.Cipher != keystoreCipher {
+		return e(fmt.Errorf("unsupported kdf %s or cipher %s", ks.Crypto.KDF, ks.Crypto.Cipher))
+	}
+
+	if ks.Crypto.KDFParams.DKLen != keystoreScryptDKLen {
+		return e(fmt.Errorf("unsupported key length %d", ks.Crypto.KDFParams.DKLen))
+	}
+
+	aead, err := keystoreAEAD(passphrase, ks.Crypto.KDFParams)
+	if err != nil {
+		return e(err)
+	}
+
+	nonce, err := hex.DecodeString(ks.Crypto.Nonce)
+	if err != nil {
+		return e(err)
+	}
+
+	if len(nonce) != aead.NonceSize() {
+		return e(errors.New("malformed nonce"))
+	}
+
+	ciphertext, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return e(err)
+	}
+
+	rawKey, err := aead.Open(nil, nonce, ciphertext, []byte(ks.Address))
+	if err != nil {
+		return e(errors.New("wrong passphrase or corrupted keystore"))
+	}
+
+	key, err := parsePrivateKey(rawKey)
+	if err != nil {
+		return e(err)
+	}
+
+	if newPrivateKeySigner(key).Address() != ks.Address {
+		return e(errors.New("address doesn't match the private key"))
+	}
+
+	return key, nil
+}
@@ -0,0 +1,173 @@
+package commercio
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// testPrivateKey returns the hex-encoded private key of the test mnemonic, and the SDK built from it.
+func testPrivateKey(t *testing.T) (string, *SDK) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	return hex.EncodeToString(sdk.signer.(privateKeySigner).key.Serialize()), sdk
+}
+
+func TestNewSDKFromPrivateKey(t *testing.T) {
+	privateKey, mnemonicSDK := testPrivateKey(t)
+
+	tests := []struct {
+		name       string
+		privateKey string
+		config     SDKConfig
+		wantErr    bool
+	}{
+		{
+			"invalid config",
+			privateKey,
+			SDKConfig{},
+			true,
+		},
+		{
+			"malformed hex",
+			"zz",
+			DefaultSDKConfig,
+			true,
+		},
+		{
+			"wrong length",
+			privateKey[:62],
+			DefaultSDKConfig,
+			true,
+		},
+		{
+			"zero key",
+			strings.Repeat("00", 32),
+			DefaultSDKConfig,
+			true,
+		},
+		{
+			"key bigger than the curve order",
+			strings.Repeat("ff", 32),
+			DefaultSDKConfig,
+			true,
+		},
+		{
+			"well-formed key",
+			privateKey,
+			DefaultSDKConfig,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := NewSDKFromPrivateKey(tt.privateKey, tt.config)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, mnemonicSDK.Address, res.Address)
+			require.Equal(t, mnemonicSDK.PublicKey, res.PublicKey)
+		})
+	}
+}
+
+func TestSDK_ExportKeystore(t *testing.T) {
+	defer func(n int) { keystoreScryptN = n }(keystoreScryptN)
+	keystoreScryptN = 1 << 10
+
+	_, sdk := testPrivateKey(t)
+
+	dir, err := ioutil.TempDir("", "commercio-sdk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "keystore.json")
+
+	require.Error(t, sdk.ExportKeystore(path, ""))
+	require.NoError(t, sdk.ExportKeystore(path, "passphrase"))
+
+	res, err := NewSDKFromKeystore(path, "passphrase", DefaultSDKConfig)
+	require.NoError(t, err)
+	require.Equal(t, sdk.Address, res.Address)
+	require.Equal(t, sdk.PublicKey, res.PublicKey)
+
+	_, err = NewSDKFromKeystore(path, "wrong passphrase", DefaultSDKConfig)
+	require.True(t, errors.Is(err, ErrNewSDK))
+
+	_, err = NewSDKFromKeystore(filepath.Join(dir, "missing.json"), "passphrase", DefaultSDKConfig)
+	require.Error(t, err)
+
+	// signers not holding the private key cannot be exported
+	fake, err := NewSDKWithSigner(fakeSigner{publicKey: secp256k1.GenPrivKey().PubKey()}, DefaultSDKConfig)
+	require.NoError(t, err)
+	require.True(t, errors.Is(fake.ExportKeystore(path, "passphrase"), ErrKeystore))
+}
+
+func Test_decryptKeystore(t *testing.T) {
+	defer func(n int) { keystoreScryptN = n }(keystoreScryptN)
+	keystoreScryptN = 1 << 10
+
+	_, sdk := testPrivateKey(t)
+
+	data, err := encryptKeystore(sdk.signer.(privateKeySigner), "passphrase")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		tamper func(ks *keystoreFile)
+	}{
+		{
+			"unsupported version",
+			func(ks *keystoreFile) { ks.Version = 42 },
+		},
+		{
+			"unsupported kdf",
+			func(ks *keystoreFile) { ks.Crypto.KDF = "pbkdf2" },
+		},
+		{
+			"unsupported key length",
+			func(ks *keystoreFile) { ks.Crypto.KDFParams.DKLen = 16 },
+		},
+		{
+			"invalid scrypt parameters",
+			func(ks *keystoreFile) { ks.Crypto.KDFParams.N = 3 },
+		},
+		{
+			"malformed nonce",
+			func(ks *keystoreFile) { ks.Crypto.Nonce = "00" },
+		},
+		{
+			"tampered address",
+			func(ks *keystoreFile) { ks.Address = "did:com:tampered" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ks keystoreFile
+			require.NoError(t, json.Unmarshal(data, &ks))
+
+			tt.tamper(&ks)
+
+			tampered, err := json.Marshal(ks)
+			require.NoError(t, err)
+
+			res, err := decryptKeystore(tampered, "passphrase")
+			require.True(t, errors.Is(err, ErrKeystore))
+			require.Nil(t, res)
+		})
+	}
+}
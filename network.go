@@ -0,0 +1,117 @@
+package commercio
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Network identifies a well-known commercio.network chain.
+type Network string
+
+const (
+	// NetworkTestnet represents the commercio.network public test chain.
+	NetworkTestnet Network = "testnet"
+)
+
+// networkPreset holds the endpoints and chain ID of a well-known Network.
+type networkPreset struct {
+	lcdEndpoint string
+	rpcEndpoint string
+	chainID     string
+}
+
+var (
+	networkPresetsMu sync.RWMutex
+
+	// networkPresets only lists endpoints published by commercio.network, callers can add the others with
+	// RegisterNetwork: the testnet LCD is the one used in
+	// https://github.com/commercionetwork/commercionetwork/blob/master/docs/developers/listing-transactions.md.
+	// Chain IDs are not listed, since they change with every chain upgrade (e.g. commercio-testnet3000, then
+	// commercio-testnet5000), nor are RPC endpoints, which are not published.
+	networkPresets = map[Network]networkPreset{
+		NetworkTestnet: {
+			lcdEndpoint: "https://lcd-testnet.commercio.network",
+		},
+	}
+)
+
+// RegisterNetwork makes NetworkConfig return configs talking to the chain chainID through lcdEndpoint and
+// rpcEndpoint for n, replacing its previous endpoints if n is already known.
+// rpcEndpoint and chainID can be empty, the configs returned by NetworkConfig will have no RPCEndpoint or
+// ChainID then.
+// RegisterNetwork is safe for concurrent use.
+func RegisterNetwork(n Network, lcdEndpoint, rpcEndpoint, chainID string) error {
+	if n == "" {
+		return errors.New("missing network name")
+	}
+
+	config := DefaultSDKConfig
+	config.LCDEndpoint = lcdEndpoint
+	config.RPCEndpoint = rpcEndpoint
+	config.ChainID = chainID
+	if err := config.validate(); err != nil {
+		return fmt.Errorf("invalid network %q: %w", n, err)
+	}
+
+	networkPresetsMu.Lock()
+	defer networkPresetsMu.Unlock()
+
+	networkPresets[n] = networkPreset{
+		lcdEndpoint: lcdEndpoint,
+		rpcEndpoint: rpcEndpoint,
+		chainID:     chainID,
+	}
+
+	return nil
+}
+
+// NetworkConfig returns DefaultSDKConfig configured to talk to n.
+// The config returned for NetworkTestnet has no ChainID nor RPCEndpoint: set them to the ones of the chain
+// version you expect to talk to, so that the SDK refuses to sign for any other chain, or register them with
+// RegisterNetwork.
+func NetworkConfig(n Network) (SDKConfig, error) {
+	networkPresetsMu.RLock()
+	preset, ok := networkPresets[n]
+	networkPresetsMu.RUnlock()
+
+	if !ok {
+		return SDKConfig{}, fmt.Errorf("unknown network %q", n)
+	}
+
+	config := DefaultSDKConfig
+	config.LCDEndpoint = preset.lcdEndpoint
+	config.RPCEndpoint = preset.rpcEndpoint
+	config.ChainID = preset.chainID
+
+	return config, nil
+}
+
+// lcdChainID returns the ID of the chain the pre-defined LCD is connected to.
+func (sdk *SDK) lcdChainID() (string, error) {
+	ni, err := sdk.nodeInfo()
+	if err != nil {
+		return "", fmt.Errorf("could not get LCD node informations: %w", err)
+	}
+
+	return ni.Info.Network, nil
+}
+
+// checkChainID returns ErrChainIDMismatch if the SDK has been configured with a chain ID different from chainID.
+func (sdk *SDK) checkChainID(chainID string) error {
+	if sdk.config.ChainID != "" && sdk.config.ChainID != chainID {
+		return fmt.Errorf("%w, expected %s but got %s", ErrChainIDMismatch, sdk.config.ChainID, chainID)
+	}
+
+	return nil
+}
+
+// verifyChainID checks that the pre-defined LCD is connected to the configured chain.
+func (sdk *SDK) verifyChainID() error {
+	chainID, err := sdk.lcdChainID()
+	if err != nil {
+		return err
+	}
+
+	return sdk.checkChainID(chainID)
+}
@@ -0,0 +1,171 @@
+package commercio
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		network         Network
+		wantLCDEndpoint string
+		wantErr         bool
+	}{
+		{
+			"testnet",
+			NetworkTestnet,
+			"https://lcd-testnet.commercio.network",
+			false,
+		},
+		{
+			"unknown network",
+			Network("moonnet"),
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := NetworkConfig(tt.network)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, SDKConfig{}, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, res.validate())
+			require.Equal(t, tt.wantLCDEndpoint, res.LCDEndpoint)
+			require.Empty(t, res.RPCEndpoint)
+			require.Empty(t, res.ChainID)
+		})
+	}
+}
+
+func TestRegisterNetwork(t *testing.T) {
+	tests := []struct {
+		name        string
+		network     Network
+		lcdEndpoint string
+		rpcEndpoint string
+		chainID     string
+		wantErr     bool
+	}{
+		{"missing name", "", "https://lcd.example.com", "", "", true},
+		{"missing LCD endpoint", "example", "", "", "", true},
+		{"malformed LCD endpoint", "example", "lcd.example.com", "", "", true},
+		{"malformed RPC endpoint", "example", "https://lcd.example.com", "rpc.example.com", "", true},
+		{"only LCD endpoint", "example", "https://lcd.example.com", "", "", false},
+		{"all fields", "example", "https://lcd.example.com", "https://rpc.example.com", "example-1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				networkPresetsMu.Lock()
+				delete(networkPresets, tt.network)
+				networkPresetsMu.Unlock()
+			}()
+
+			err := RegisterNetwork(tt.network, tt.lcdEndpoint, tt.rpcEndpoint, tt.chainID)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				_, err = NetworkConfig(tt.network)
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			res, err := NetworkConfig(tt.network)
+			require.NoError(t, err)
+			require.NoError(t, res.validate())
+			require.Equal(t, tt.lcdEndpoint, res.LCDEndpoint)
+			require.Equal(t, tt.rpcEndpoint, res.RPCEndpoint)
+			require.Equal(t, tt.chainID, res.ChainID)
+		})
+	}
+}
+
+func TestNewSDK_verifyChainID(t *testing.T) {
+	tests := []struct {
+		name         string
+		responder    httpmock.Responder
+		wantErr      bool
+		wantMismatch bool
+	}{
+		{
+			"error from the LCD endpoint",
+			httpmock.NewJsonResponderOrPanic(http.StatusInternalServerError, sacco.Error{Error: "error!"}),
+			true,
+			false,
+		},
+		{
+			"unexpected chain",
+			httpmock.NewStringResponder(http.StatusOK, `{"node_info":{"network":"commercio-mainnet"}}`),
+			true,
+			true,
+		},
+		{
+			"expected chain",
+			httpmock.NewStringResponder(http.StatusOK, `{"node_info":{"network":"commercio-testnet"}}`),
+			false,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/node_info", tt.responder)
+
+			config := DefaultSDKConfig
+			config.ChainID = "commercio-testnet"
+			config.VerifyChainID = true
+
+			res, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", config)
+
+			if tt.wantErr {
+				require.True(t, errors.Is(err, ErrNewSDK))
+				require.Equal(t, tt.wantMismatch, errors.Is(err, ErrChainIDMismatch))
+				require.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, res)
+		})
+	}
+}
+
+func TestSDK_chainIDMismatch(t *testing.T) {
+	config := DefaultSDKConfig
+	config.ChainID = "commercio-mainnet"
+
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", config)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerAccountResponders(sdk, 10, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "ok!"}))
+
+	_, err = sdk.SendTransaction(MsgSend{})
+	require.True(t, errors.Is(err, ErrChainIDMismatch))
+	require.Equal(t, 0, httpmock.GetCallCountInfo()["POST http://localhost:1317/txs"])
+
+	_, err = sdk.SignTx(UnsignedTx{}, 42, 10, "commercio-testnet")
+	require.True(t, errors.Is(err, ErrSigning))
+	require.True(t, errors.Is(err, ErrChainIDMismatch))
+
+	_, err = sdk.SignTx(UnsignedTx{}, 42, 10, "commercio-mainnet")
+	require.NoError(t, err)
+}
@@ -0,0 +1,140 @@
+package commercio
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// UnsignedTx is a transaction ready to be signed.
+// Its JSON representation is a Cosmos StdTx with no signatures, so it can be saved and moved around freely.
+type UnsignedTx sacco.TransactionPayload
+
+// SignedTx is a transaction signed by SignTx, ready to be broadcasted.
+// Its JSON representation is a Cosmos StdTx, so it can be saved and moved around freely.
+type SignedTx sacco.SignedTransactionPayload
+
+// BuildUnsignedTx builds a transaction containing rawMsgs exactly as SendTransaction does, without signing it.
+func (sdk *SDK) BuildUnsignedTx(rawMsgs ...interface{}) (UnsignedTx, error) {
+	txp, err := sdk.prepareTx(rawMsgs...)
+	if err != nil {
+		return UnsignedTx{}, err
+	}
+
+	return UnsignedTx(txp), nil
+}
+
+// SignTx signs unsigned with the SDK private key, given the signer account number and sequence and the chain ID
+// of the network the transaction will be broadcasted to.
+// SignTx doesn't perform any network operation.
+// If the SDK has been configured with a ChainID, SignTx refuses to sign for any other chain, returning an error
+// matching both ErrSigning and ErrChainIDMismatch.
+func (sdk *SDK) SignTx(unsigned UnsignedTx, accountNumber, sequence uint64, chainID string) (SignedTx, error) {
+	if err := sdk.checkChainID(chainID); err != nil {
+		return SignedTx{}, wrap(ErrSigning, err)
+	}
+
+	e := func(ext error) (SignedTx, error) {
+		return SignedTx{}, fmt.Errorf("%w, %s", ErrSigning, ext.Error())
+	}
+
+	signBytes, err := json.Marshal(sacco.TransactionSignature{
+		AccountNumber: strconv.FormatUint(accountNumber, 10),
+		ChainID:       chainID,
+		Fee:           unsigned.Fee,
+		Sequence:      strconv.FormatUint(sequence, 10),
+		Memo:          unsigned.Memo,
+		Msgs:          unsigned.Message,
+	})
+	if err != nil {
+		return e(err)
+	}
+
+	signBytes, err = types.SortJSON(signBytes)
+	if err != nil {
+		return e(err)
+	}
+
+	signature, err := signWith(sdk.signer, signBytes)
+	if err != nil {
+		return e(err)
+	}
+
+	pk, err := signerPublicKey(sdk.signer)
+	if err != nil {
+		return e(err)
+	}
+
+	unsigned.Signatures = []sacco.Signature{
+		{
+			Signature: base64.StdEncoding.EncodeToString(signature),
+			SigPubKey: sacco.SigPubKey{
+				Type:  "tendermint/PubKeySecp256k1",
+				Value: base64.StdEncoding.EncodeToString(pk[:]),
+			},
+		},
+	}
+
+	return SignedTx(unsigned), nil
+}
+
+// BroadcastSignedTx broadcasts signed through the pre-defined LCD, then returns the transaction hash.
+func (sdk *SDK) BroadcastSignedTx(signed SignedTx) (string, error) {
+	txr, err := sdk.broadcast(signed)
+	if err != nil {
+		return "", err
+	}
+
+	return txr.TxHash, nil
+}
+
+// broadcast broadcasts signed through the pre-defined LCD, then returns the LCD response.
+// If the LCD rejected the transaction, both its response and an error are returned.
+func (sdk *SDK) broadcast(signed SignedTx) (sacco.TxResponse, error) {
+	e := func(ext error) (sacco.TxResponse, error) {
+		return sacco.TxResponse{}, fmt.Errorf("%w, %s", ErrBroadcast, ext.Error())
+	}
+
+	requestBody, err := json.Marshal(sacco.TxBody{
+		Tx:   sacco.SignedTransactionPayload(signed),
+		Mode: sdk.config.Mode.asSaccoMode().String(),
+	})
+	if err != nil {
+		return e(err)
+	}
+
+	resp, err := http.Post(fmt.Sprintf("%s/txs", sdk.config.LCDEndpoint), "application/json", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return e(err)
+	}
+
+	defer resp.Body.Close()
+
+	jdec := json.NewDecoder(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		var jerr sacco.Error
+		if err := jdec.Decode(&jerr); err != nil {
+			return e(fmt.Errorf("could not decode error response with status %d: %w", resp.StatusCode, err))
+		}
+
+		return e(fmt.Errorf("%s", jerr.Error))
+	}
+
+	var txr sacco.TxResponse
+	if err := jdec.Decode(&txr); err != nil {
+		return e(fmt.Errorf("could not decode LCD response: %w", err))
+	}
+
+	if txr.Code != 0 {
+		return txr, fmt.Errorf("%w, codespace %s: %s, code %d", ErrBroadcast, txr.Codespace, txr.RawLog, txr.Code)
+	}
+
+	return txr, nil
+}
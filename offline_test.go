@@ -0,0 +1,163 @@
+package commercio
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// roundTrip writes in to a file as JSON, then reads it back into out.
+func roundTrip(t *testing.T, in, out interface{}) {
+	dir, err := ioutil.TempDir("", "commercio-sdk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "tx.json")
+
+	data, err := json.Marshal(in)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+
+	read, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(read, out))
+}
+
+func TestSDK_offlineSigning(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	addr, err := Address(testAddress)
+	require.NoError(t, err)
+
+	// build
+	unsigned, err := sdk.BuildUnsignedTx(MsgSend{FromAddress: addr, ToAddress: addr})
+	require.NoError(t, err)
+	require.Empty(t, unsigned.Signatures)
+
+	var readUnsigned UnsignedTx
+	roundTrip(t, unsigned, &readUnsigned)
+
+	// sign
+	signed, err := sdk.SignTx(readUnsigned, 42, 7, "commercio-testnet")
+	require.NoError(t, err)
+	require.Len(t, signed.Signatures, 1)
+
+	var readSigned SignedTx
+	roundTrip(t, signed, &readSigned)
+
+	// check the signature against the sign bytes
+	signBytes, err := json.Marshal(sacco.TransactionSignature{
+		AccountNumber: "42",
+		ChainID:       "commercio-testnet",
+		Fee:           unsigned.Fee,
+		Sequence:      "7",
+		Memo:          unsigned.Memo,
+		Msgs:          unsigned.Message,
+	})
+	require.NoError(t, err)
+
+	rawPubKey, err := base64.StdEncoding.DecodeString(readSigned.Signatures[0].SigPubKey.Value)
+	require.NoError(t, err)
+	rawSig, err := base64.StdEncoding.DecodeString(readSigned.Signatures[0].Signature)
+	require.NoError(t, err)
+
+	var pubKey secp256k1.PubKeySecp256k1
+	copy(pubKey[:], rawPubKey)
+	require.True(t, pubKey.VerifyBytes(types.MustSortJSON(signBytes), rawSig))
+	require.Equal(t, sdk.Address, types.AccAddress(pubKey.Address()).String())
+
+	// broadcast
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var sentBody sacco.TxBody
+	httpmock.RegisterResponder(http.MethodPost, "http://localhost:1317/txs", func(req *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(req.Body).Decode(&sentBody); err != nil {
+			return nil, err
+		}
+
+		return httpmock.NewJsonResponse(http.StatusOK, sacco.TxResponse{TxHash: "ok!"})
+	})
+
+	hash, err := sdk.BroadcastSignedTx(readSigned)
+	require.NoError(t, err)
+	require.Equal(t, "ok!", hash)
+	require.Equal(t, sacco.SignedTransactionPayload(signed), sentBody.Tx)
+	require.Equal(t, TxModeSync, sentBody.Mode)
+}
+
+func TestSDK_BuildUnsignedTx(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	res, err := sdk.BuildUnsignedTx()
+	require.Error(t, err)
+	require.Equal(t, UnsignedTx{}, res)
+}
+
+func TestSDK_BroadcastSignedTx(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		responder httpmock.Responder
+		wantErr   bool
+	}{
+		{
+			"error from the LCD endpoint",
+			httpmock.NewJsonResponderOrPanic(http.StatusForbidden, sacco.Error{Error: "error!"}),
+			true,
+		},
+		{
+			"error from the LCD endpoint with malformed body",
+			httpmock.NewStringResponder(http.StatusForbidden, "aaa"),
+			true,
+		},
+		{
+			"malformed LCD response",
+			httpmock.NewStringResponder(http.StatusOK, "aaa"),
+			true,
+		},
+		{
+			"transaction rejected",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{Code: 4, Codespace: "sdk", RawLog: "unauthorized"}),
+			true,
+		},
+		{
+			"transaction accepted",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "ok!"}),
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder(http.MethodPost, "http://localhost:1317/txs", tt.responder)
+
+			res, err := sdk.BroadcastSignedTx(SignedTx{})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, "", res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "ok!", res)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package commercio
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// pairwiseAccount is the BIP44 account pairwise keys are derived from, so that they never overlap the main
+// account keys.
+const pairwiseAccount = 1000
+
+// maxPairwiseCounter is the number of times the derivation of a pairwise key is repeated, at most, when the
+// derived addresses are already associated to other counterparties.
+const maxPairwiseCounter = 1000
+
+// PairwiseManager derives a pairwise identity for each counterparty the main account has a relationship with.
+// Pairwise keys are derived deterministically from the AccountManager mnemonic along
+// m/44'/118'/1000'/0/index, with index computed from the counterparty DID, so that they can be recovered from the
+// mnemonic alone.
+// Derived pairwise identities are recorded in a PairwiseRegistry.
+type PairwiseManager struct {
+	accounts *AccountManager
+	registry PairwiseRegistry
+}
+
+// NewPairwiseManager returns a new PairwiseManager deriving pairwise identities with accounts, and recording them
+// in registry.
+// The main account is the one derived by accounts along m/44'/118'/0'/0/0.
+func NewPairwiseManager(accounts *AccountManager, registry PairwiseRegistry) (*PairwiseManager, error) {
+	if accounts == nil {
+		return nil, fmt.Errorf("%w, %s", ErrPairwise, "missing account manager")
+	}
+
+	if registry == nil {
+		return nil, fmt.Errorf("%w, %s", ErrPairwise, "missing registry")
+	}
+
+	return &PairwiseManager{
+		accounts: accounts,
+		registry: registry,
+	}, nil
+}
+
+// Main returns the SDK of the main account.
+func (pm *PairwiseManager) Main() (*SDK, error) {
+	return pm.accounts.Account(0, 0)
+}
+
+// Pairwise returns the SDK of the pairwise identity associated to counterparty, which is a Bech32-encoded DID,
+// and records it in the registry.
+// Since derivation indexes only have 31 bits, two counterparties can be associated to the same pairwise key:
+// if the registry already associates the derived address to another counterparty, the index is derived again
+// from counterparty and an increasing counter until a free address is found, and the counter is recorded in the
+// registry, so that pairwise identities are never shared.
+func (pm *PairwiseManager) Pairwise(counterparty string) (*SDK, error) {
+	e := func(ext error) (*SDK, error) {
+		return nil, fmt.Errorf("%w, %s", ErrPairwise, ext.Error())
+	}
+
+	if _, err := types.AccAddressFromBech32(counterparty); err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrInvalidAddress, err.Error())
+	}
+
+	identity, ok, err := pm.registry.Get(counterparty)
+	if err != nil {
+		return e(err)
+	}
+
+	if ok {
+		sdk, err := pm.accounts.Account(pairwiseAccount, pairwiseIndex(counterparty, identity.Counter))
+		if err != nil {
+			return e(err)
+		}
+
+		if identity.Address != sdk.Address {
+			return e(fmt.Errorf("registry associates %s to %s, but %s has been derived", counterparty, identity.Address, sdk.Address))
+		}
+
+		return sdk, nil
+	}
+
+	all, err := pm.registry.All()
+	if err != nil {
+		return e(err)
+	}
+
+	taken := make(map[string]struct{}, len(all))
+	for _, other := range all {
+		taken[other.Address] = struct{}{}
+	}
+
+	for counter := uint32(0); counter <= maxPairwiseCounter; counter++ {
+		sdk, err := pm.accounts.Account(pairwiseAccount, pairwiseIndex(counterparty, counter))
+		if err != nil {
+			return e(err)
+		}
+
+		if _, ok := taken[sdk.Address]; ok {
+			continue
+		}
+
+		if err := pm.registry.Put(counterparty, PairwiseIdentity{Address: sdk.Address, Counter: counter}); err != nil {
+			return e(err)
+		}
+
+		return sdk, nil
+	}
+
+	return e(fmt.Errorf("no free pairwise address found for %s", counterparty))
+}
+
+// PairwiseAddress returns the address of the pairwise identity associated to counterparty, and records it in the
+// registry.
+func (pm *PairwiseManager) PairwiseAddress(counterparty string) (types.AccAddress, error) {
+	sdk, err := pm.Pairwise(counterparty)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.AccAddressFromBech32(sdk.Address)
+}
+
+// Pairwises returns the addresses of the pairwise identities recorded in the registry, indexed by counterparty.
+func (pm *PairwiseManager) Pairwises() (map[string]string, error) {
+	all, err := pm.registry.All()
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrPairwise, err.Error())
+	}
+
+	addresses := make(map[string]string, len(all))
+	for counterparty, identity := range all {
+		addresses[counterparty] = identity.Address
+	}
+
+	return addresses, nil
+}
+
+// PowerUpParams returns the PowerUpParams the main account can use to request amount to be sent to the pairwise
+// identity associated to counterparty.
+// tumblerKey and signatureKey are used as described in PowerUpParams.
+func (pm *PairwiseManager) PowerUpParams(counterparty string, amount uint64, tumblerKey, signatureKey io.Reader) (PowerUpParams, error) {
+	main, err := pm.Main()
+	if err != nil {
+		return PowerUpParams{}, err
+	}
+
+	pairwise, err := pm.PairwiseAddress(counterparty)
+	if err != nil {
+		return PowerUpParams{}, err
+	}
+
+	params := PowerUpParams{
+		PubKey:          main.PublicKey,
+		TumblerKey:      tumblerKey,
+		SignatureKey:    signatureKey,
+		Amount:          amount,
+		PairwiseAddress: pairwise,
+	}
+
+	if err := params.validate(); err != nil {
+		return PowerUpParams{}, fmt.Errorf("%w, %s", ErrInvalidPowerupParams, err.Error())
+	}
+
+	return params, nil
+}
+
+// pairwiseIndex returns the derivation index of the pairwise key associated to counterparty: the first 31 bits
+// of the SHA-256 of counterparty if counter is zero, of counterparty followed by the big endian counter
+// otherwise.
+func pairwiseIndex(counterparty string, counter uint32) uint32 {
+	data := []byte(counterparty)
+	if counter != 0 {
+		data = append(data, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(data[len(counterparty):], counter)
+	}
+
+	sum := sha256.Sum256(data)
+	return binary.BigEndian.Uint32(sum[:4]) &^ hdkeychain.HardenedKeyStart
+}
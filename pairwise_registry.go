@@ -0,0 +1,157 @@
+package commercio
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PairwiseIdentity is a pairwise identity recorded in a PairwiseRegistry.
+type PairwiseIdentity struct {
+	// Address is the Bech32-encoded address of the pairwise identity.
+	Address string
+
+	// Counter is the number of times the derivation has been repeated for the counterparty, because the
+	// previously derived addresses were already associated to other counterparties.
+	Counter uint32
+}
+
+// PairwiseRegistry keeps track of the pairwise identities a PairwiseManager derived, each associated to the
+// counterparty it has been derived for.
+// Implementations must be safe for concurrent use.
+type PairwiseRegistry interface {
+	// Get returns the pairwise identity associated to counterparty, and false if there's none.
+	Get(counterparty string) (PairwiseIdentity, bool, error)
+
+	// Put associates identity to counterparty.
+	Put(counterparty string, identity PairwiseIdentity) error
+
+	// All returns all the pairwise identities, indexed by counterparty.
+	All() (map[string]PairwiseIdentity, error)
+}
+
+// MemoryPairwiseRegistry is a PairwiseRegistry keeping pairwise identities in memory.
+type MemoryPairwiseRegistry struct {
+	mu         sync.Mutex
+	identities map[string]PairwiseIdentity
+}
+
+// NewMemoryPairwiseRegistry returns an empty MemoryPairwiseRegistry.
+func NewMemoryPairwiseRegistry() *MemoryPairwiseRegistry {
+	return &MemoryPairwiseRegistry{
+		identities: map[string]PairwiseIdentity{},
+	}
+}
+
+// Get implements PairwiseRegistry.
+func (m *MemoryPairwiseRegistry) Get(counterparty string) (PairwiseIdentity, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	identity, ok := m.identities[counterparty]
+	return identity, ok, nil
+}
+
+// Put implements PairwiseRegistry.
+func (m *MemoryPairwiseRegistry) Put(counterparty string, identity PairwiseIdentity) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.identities[counterparty] = identity
+	return nil
+}
+
+// All implements PairwiseRegistry.
+func (m *MemoryPairwiseRegistry) All() (map[string]PairwiseIdentity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	all := make(map[string]PairwiseIdentity, len(m.identities))
+	for counterparty, identity := range m.identities {
+		all[counterparty] = identity
+	}
+
+	return all, nil
+}
+
+// FilePairwiseRegistry is a PairwiseRegistry persisting pairwise identities in a file, one
+// "<counterparty> <address> <counter>" line per identity.
+// Lines without the counter, written by previous versions, are loaded with a zero counter.
+type FilePairwiseRegistry struct {
+	memory *MemoryPairwiseRegistry
+
+	mu  sync.Mutex
+	log *appendLog
+}
+
+// NewFilePairwiseRegistry returns a FilePairwiseRegistry persisting pairwise identities in the file at path,
+// loading the ones already stored there.
+// The file is created if it doesn't exist.
+func NewFilePairwiseRegistry(path string) (*FilePairwiseRegistry, error) {
+	memory := NewMemoryPairwiseRegistry()
+
+	log, err := openAppendLog(path, "pairwise registry file", func(line string) error {
+		fields := strings.Fields(line)
+		if len(fields) != 2 && len(fields) != 3 {
+			return fmt.Errorf("malformed pairwise registry line: %s", line)
+		}
+
+		identity := PairwiseIdentity{Address: fields[1]}
+		if len(fields) == 3 {
+			counter, err := strconv.ParseUint(fields[2], 10, 32)
+			if err != nil {
+				return fmt.Errorf("malformed pairwise registry line: %s", line)
+			}
+
+			identity.Counter = uint32(counter)
+		}
+
+		// later lines override earlier ones
+		memory.identities[fields[0]] = identity
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FilePairwiseRegistry{
+		memory: memory,
+		log:    log,
+	}, nil
+}
+
+// Get implements PairwiseRegistry.
+func (f *FilePairwiseRegistry) Get(counterparty string) (PairwiseIdentity, bool, error) {
+	return f.memory.Get(counterparty)
+}
+
+// Put implements PairwiseRegistry.
+func (f *FilePairwiseRegistry) Put(counterparty string, identity PairwiseIdentity) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if current, ok, _ := f.memory.Get(counterparty); ok && current == identity {
+		return nil
+	}
+
+	line := counterparty + " " + identity.Address + " " + strconv.FormatUint(uint64(identity.Counter), 10)
+	if err := f.log.append(line); err != nil {
+		return err
+	}
+
+	return f.memory.Put(counterparty, identity)
+}
+
+// All implements PairwiseRegistry.
+func (f *FilePairwiseRegistry) All() (map[string]PairwiseIdentity, error) {
+	return f.memory.All()
+}
+
+// Close closes the file backing f.
+func (f *FilePairwiseRegistry) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.log.close()
+}
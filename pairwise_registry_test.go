@@ -0,0 +1,88 @@
+package commercio
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryPairwiseRegistry(t *testing.T) {
+	registry := NewMemoryPairwiseRegistry()
+
+	_, ok, err := registry.Get("counterparty")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	identity := PairwiseIdentity{Address: "address", Counter: 1}
+	require.NoError(t, registry.Put("counterparty", identity))
+
+	got, ok, err := registry.Get("counterparty")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, identity, got)
+
+	all, err := registry.All()
+	require.NoError(t, err)
+	require.Equal(t, map[string]PairwiseIdentity{"counterparty": identity}, all)
+
+	// the returned map is a copy
+	all["other"] = PairwiseIdentity{Address: "other"}
+	_, ok, _ = registry.Get("other")
+	require.False(t, ok)
+}
+
+func TestFilePairwiseRegistry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "commercio-sdk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = NewFilePairwiseRegistry(filepath.Join(dir, "missing", "registry"))
+	require.Error(t, err)
+
+	malformed := filepath.Join(dir, "malformed")
+	require.NoError(t, ioutil.WriteFile(malformed, []byte("counterparty\n"), 0600))
+	_, err = NewFilePairwiseRegistry(malformed)
+	require.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(malformed, []byte("counterparty address counter\n"), 0600))
+	_, err = NewFilePairwiseRegistry(malformed)
+	require.Error(t, err)
+
+	path := filepath.Join(dir, "registry")
+
+	registry, err := NewFilePairwiseRegistry(path)
+	require.NoError(t, err)
+
+	require.NoError(t, registry.Put("first", PairwiseIdentity{Address: "a"}))
+	require.NoError(t, registry.Put("second", PairwiseIdentity{Address: "b"}))
+	require.NoError(t, registry.Put("first", PairwiseIdentity{Address: "a"}))
+	require.NoError(t, registry.Put("second", PairwiseIdentity{Address: "c", Counter: 2}))
+	require.NoError(t, registry.Close())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "first a 0\nsecond b 0\nsecond c 2\n", string(data))
+
+	// lines without the counter are still loaded
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = file.WriteString("third d\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	// identities are loaded back when the file gets opened again, latest ones win
+	registry, err = NewFilePairwiseRegistry(path)
+	require.NoError(t, err)
+	defer registry.Close()
+
+	all, err := registry.All()
+	require.NoError(t, err)
+	require.Equal(t, map[string]PairwiseIdentity{
+		"first":  {Address: "a"},
+		"second": {Address: "c", Counter: 2},
+		"third":  {Address: "d"},
+	}, all)
+}
@@ -0,0 +1,131 @@
+package commercio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// testPairwiseManager returns a PairwiseManager deriving from the test mnemonic, recording into registry.
+func testPairwiseManager(t *testing.T, registry PairwiseRegistry) *PairwiseManager {
+	am, err := NewAccountManager("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	pm, err := NewPairwiseManager(am, registry)
+	require.NoError(t, err)
+
+	return pm
+}
+
+func TestNewPairwiseManager(t *testing.T) {
+	am, err := NewAccountManager("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	_, err = NewPairwiseManager(nil, NewMemoryPairwiseRegistry())
+	require.True(t, errors.Is(err, ErrPairwise))
+
+	_, err = NewPairwiseManager(am, nil)
+	require.True(t, errors.Is(err, ErrPairwise))
+}
+
+func TestPairwiseManager_Pairwise(t *testing.T) {
+	registry := NewMemoryPairwiseRegistry()
+	pm := testPairwiseManager(t, registry)
+
+	first := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String()
+	second := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String()
+
+	main, err := pm.Main()
+	require.NoError(t, err)
+	require.Equal(t, testAddress, main.Address)
+
+	firstSDK, err := pm.Pairwise(first)
+	require.NoError(t, err)
+	require.NotEqual(t, main.Address, firstSDK.Address)
+
+	secondSDK, err := pm.Pairwise(second)
+	require.NoError(t, err)
+	require.NotEqual(t, firstSDK.Address, secondSDK.Address)
+
+	// derivation is deterministic, even across managers
+	again, err := testPairwiseManager(t, NewMemoryPairwiseRegistry()).PairwiseAddress(first)
+	require.NoError(t, err)
+	require.Equal(t, firstSDK.Address, again.String())
+
+	all, err := pm.Pairwises()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{first: firstSDK.Address, second: secondSDK.Address}, all)
+
+	// invalid counterparty
+	_, err = pm.Pairwise("counterparty")
+	require.True(t, errors.Is(err, ErrInvalidAddress))
+
+	// registry inconsistent with the derived keys
+	require.NoError(t, registry.Put(first, PairwiseIdentity{Address: secondSDK.Address}))
+	_, err = pm.Pairwise(first)
+	require.True(t, errors.Is(err, ErrPairwise))
+}
+
+func TestPairwiseManager_Pairwise_collision(t *testing.T) {
+	registry := NewMemoryPairwiseRegistry()
+	pm := testPairwiseManager(t, registry)
+
+	counterparty := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String()
+	other := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String()
+
+	address, err := testPairwiseManager(t, NewMemoryPairwiseRegistry()).PairwiseAddress(counterparty)
+	require.NoError(t, err)
+
+	// another counterparty already owns the derived pairwise identity
+	require.NoError(t, registry.Put(other, PairwiseIdentity{Address: address.String()}))
+
+	sdk, err := pm.Pairwise(counterparty)
+	require.NoError(t, err)
+	require.NotEqual(t, address.String(), sdk.Address)
+
+	identity, ok, err := registry.Get(counterparty)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, PairwiseIdentity{Address: sdk.Address, Counter: 1}, identity)
+
+	// the resolution is deterministic, and the recorded counter is used afterwards
+	again, err := testPairwiseManager(t, NewMemoryPairwiseRegistry()).accounts.Account(pairwiseAccount, pairwiseIndex(counterparty, 1))
+	require.NoError(t, err)
+	require.Equal(t, sdk.Address, again.Address)
+
+	sdk, err = pm.Pairwise(counterparty)
+	require.NoError(t, err)
+	require.Equal(t, identity.Address, sdk.Address)
+}
+
+func TestPairwiseManager_PowerUpParams(t *testing.T) {
+	pm := testPairwiseManager(t, NewMemoryPairwiseRegistry())
+
+	counterparty := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String()
+
+	main, err := pm.Main()
+	require.NoError(t, err)
+
+	pairwise, err := pm.PairwiseAddress(counterparty)
+	require.NoError(t, err)
+
+	signatureKey, _ := testRSAKeypair(t)
+	_, tumblerKey := testRSAKeypair(t)
+
+	params, err := pm.PowerUpParams(counterparty, 42, strings.NewReader(tumblerKey), strings.NewReader(signatureKey))
+	require.NoError(t, err)
+	require.Equal(t, main.PublicKey, params.PubKey)
+	require.Equal(t, pairwise, params.PairwiseAddress)
+	require.Equal(t, uint64(42), params.Amount)
+
+	// the params can be used to build a power-up request
+	_, err = main.BuildPowerupRequest(params)
+	require.NoError(t, err)
+
+	_, err = pm.PowerUpParams(counterparty, 0, strings.NewReader(tumblerKey), strings.NewReader(signatureKey))
+	require.True(t, errors.Is(err, ErrInvalidPowerupParams))
+}
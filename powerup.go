@@ -0,0 +1,169 @@
+package commercio
+
+import (
+	"fmt"
+	"strings"
+
+	id "github.com/commercionetwork/commercionetwork/x/id/types"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// Power-up request statuses.
+const (
+	PowerUpStatusPending  = "pending"
+	PowerUpStatusApproved = id.StatusApproved
+	PowerUpStatusRejected = id.StatusRejected
+	PowerUpStatusCanceled = id.StatusCanceled
+)
+
+// PowerUpRequest is a power-up request sent by RequestPowerUp.
+type PowerUpRequest struct {
+	// ID is the ID of the MsgRequestDidPowerUp.
+	ID string
+
+	// TxHash is the hash of the transaction containing the MsgRequestDidPowerUp.
+	TxHash string
+
+	// Amount is the amount requested to be sent to PairwiseAddress.
+	Amount types.Coins
+
+	// PairwiseAddress is the address the requested amount will be sent to.
+	PairwiseAddress types.AccAddress
+}
+
+// PowerUpRequestStatus is the status of a power-up request, as returned by PowerUpStatus.
+type PowerUpRequestStatus struct {
+	// ID is the ID of the power-up request.
+	ID string
+
+	// Status is either PowerUpStatusPending, PowerUpStatusApproved, PowerUpStatusRejected or
+	// PowerUpStatusCanceled.
+	Status string
+
+	// Message is the message the request has been handled with, empty if it's pending.
+	Message string
+
+	// PairwiseAddress is the address the requested amount will be sent to, nil if the request hasn't been sent by
+	// RequestPowerUp.
+	PairwiseAddress types.AccAddress
+
+	// PairwiseBalance is the balance of PairwiseAddress, nil if the latter is nil.
+	PairwiseBalance types.Coins
+}
+
+// RequestPowerUp builds a MsgRequestDidPowerUp based on params and sends it, then records it in the configured
+// PowerUpStore so that its progress can be followed with PowerUpStatus.
+// If the request has been sent but cannot be recorded, it is returned along with an ErrPowerUpStore error.
+func (sdk *SDK) RequestPowerUp(params PowerUpParams) (PowerUpRequest, error) {
+	msg, err := sdk.BuildPowerupRequest(params)
+	if err != nil {
+		return PowerUpRequest{}, err
+	}
+
+	txHash, err := sdk.SendTransaction(msg)
+	if err != nil {
+		return PowerUpRequest{}, err
+	}
+
+	request := PowerUpRequest{
+		ID:              msg.ID,
+		TxHash:          txHash,
+		Amount:          msg.Amount,
+		PairwiseAddress: params.PairwiseAddress,
+	}
+
+	if err := sdk.config.PowerUpStore.Add(request); err != nil {
+		return request, fmt.Errorf("%w, %s", ErrPowerUpStore, err.Error())
+	}
+
+	return request, nil
+}
+
+// PowerUpRequests returns the power-up requests sent by RequestPowerUp recorded in the configured PowerUpStore,
+// in the order they have been sent.
+func (sdk *SDK) PowerUpRequests() ([]PowerUpRequest, error) {
+	requests, err := sdk.config.PowerUpStore.All()
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrPowerUpStore, err.Error())
+	}
+
+	return requests, nil
+}
+
+// PrunePowerUpRequests removes from the configured PowerUpStore the power-up requests which have been approved,
+// rejected or canceled, then returns their statuses.
+// Pending requests are kept, so that they can be pruned by a later call, as well as the requests whose status
+// cannot be fetched, e.g. because their transaction failed and they never reached the chain: the other requests
+// are pruned anyway, and an error listing the failed ones is returned along with the pruned statuses.
+func (sdk *SDK) PrunePowerUpRequests() ([]PowerUpRequestStatus, error) {
+	requests, err := sdk.PowerUpRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []PowerUpRequestStatus
+	var prunedIDs, failedIDs []string
+	var statusErr error
+	for _, r := range requests {
+		status, err := sdk.PowerUpStatus(r.ID)
+		if err != nil {
+			failedIDs = append(failedIDs, r.ID)
+			if statusErr == nil {
+				statusErr = err
+			}
+
+			continue
+		}
+
+		if status.Status != PowerUpStatusPending {
+			pruned = append(pruned, status)
+			prunedIDs = append(prunedIDs, r.ID)
+		}
+	}
+
+	if err := sdk.config.PowerUpStore.Remove(prunedIDs...); err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrPowerUpStore, err.Error())
+	}
+
+	if statusErr != nil {
+		return pruned, fmt.Errorf("could not get the status of requests %s: %w", strings.Join(failedIDs, ", "), statusErr)
+	}
+
+	return pruned, nil
+}
+
+// PowerUpStatus returns the status of the power-up request identified by requestID.
+// If the request has been sent by RequestPowerUp, the balance of its pairwise address is returned as well.
+func (sdk *SDK) PowerUpStatus(requestID string) (PowerUpRequestStatus, error) {
+	var request DidPowerUpRequest
+	if err := sdk.query(fmt.Sprintf("/powerUpRequest/%s", requestID), &request); err != nil {
+		return PowerUpRequestStatus{}, err
+	}
+
+	status := PowerUpRequestStatus{
+		ID:     requestID,
+		Status: PowerUpStatusPending,
+	}
+
+	if request.Status != nil {
+		status.Status = strings.ToLower(request.Status.Type)
+		status.Message = request.Status.Message
+	}
+
+	sent, ok, err := sdk.config.PowerUpStore.Get(requestID)
+	if err != nil {
+		return PowerUpRequestStatus{}, fmt.Errorf("%w, %s", ErrPowerUpStore, err.Error())
+	}
+
+	if ok {
+		balance, err := sdk.Balance(sent.PairwiseAddress)
+		if err != nil {
+			return PowerUpRequestStatus{}, err
+		}
+
+		status.PairwiseAddress = sent.PairwiseAddress
+		status.PairwiseBalance = balance
+	}
+
+	return status, nil
+}
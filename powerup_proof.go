@@ -0,0 +1,168 @@
+package commercio
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// PowerupProofOptions configures how OpenPowerupProofWithOptions opens and verifies a power-up request proof.
+type PowerupProofOptions struct {
+	// CryptoProfile is the profile of the SDK which created the proof, DefaultCryptoProfile if empty.
+	CryptoProfile CryptoProfile
+
+	// MaxAge is how old a proof can be to be accepted, ten minutes if zero.
+	MaxAge time.Duration
+
+	// MaxSkew is how far in the future a proof can be to be accepted, to tolerate clock differences.
+	// One minute if zero.
+	MaxSkew time.Duration
+}
+
+// DefaultPowerupProofOptions are the options used by OpenPowerupProof.
+var DefaultPowerupProofOptions = PowerupProofOptions{
+	CryptoProfile: DefaultCryptoProfile,
+	MaxAge:        10 * time.Minute,
+	MaxSkew:       time.Minute,
+}
+
+// withDefaults returns o with its zero fields set to the DefaultPowerupProofOptions ones.
+func (o PowerupProofOptions) withDefaults() PowerupProofOptions {
+	if o.MaxAge == 0 {
+		o.MaxAge = DefaultPowerupProofOptions.MaxAge
+	}
+
+	if o.MaxSkew == 0 {
+		o.MaxSkew = DefaultPowerupProofOptions.MaxSkew
+	}
+
+	return o
+}
+
+// validate checks that o can be used to open a proof.
+func (o PowerupProofOptions) validate() error {
+	if o.MaxAge < 0 || o.MaxSkew < 0 {
+		return errors.New("proof max age and max skew cannot be negative")
+	}
+
+	return o.CryptoProfile.validate()
+}
+
+// PowerupProof is the content of a MsgRequestDidPowerUp proof, once decrypted and verified.
+type PowerupProof struct {
+	// SenderDid is the address of the account which sent the power-up request.
+	SenderDid types.AccAddress
+
+	// PairwiseDid is the address the requested amount must be sent to.
+	PairwiseDid types.AccAddress
+
+	// Timestamp is when the proof has been created.
+	Timestamp time.Time
+}
+
+// OpenPowerupProof decrypts the proof of msg with the tumbler RSA PKCS8 private key, then verifies that it has
+// been signed by the sender with the RSA PKIX public key senderSignatureKey, which is the signature key listed in
+// its DidDocument.
+// Proofs older than ten minutes are rejected.
+// ErrDecryptionFailure or ErrTamperedCiphertext are returned if the proof cannot be decrypted, ErrInvalidProof if
+// it cannot be verified.
+// The proof must have been created with DefaultCryptoProfile, see OpenPowerupProofWithOptions for the others.
+func OpenPowerupProof(msg MsgRequestDidPowerUp, tumblerPrivateKey io.Reader, senderSignatureKey io.Reader) (PowerupProof, error) {
+	return OpenPowerupProofWithOptions(msg, tumblerPrivateKey, senderSignatureKey, DefaultPowerupProofOptions)
+}
+
+// OpenPowerupProofWithOptions works like OpenPowerupProof, with the crypto profile and accepted proof age
+// taken from options.
+// ErrInvalidProofOptions is returned if options are invalid.
+func OpenPowerupProofWithOptions(msg MsgRequestDidPowerUp, tumblerPrivateKey io.Reader, senderSignatureKey io.Reader, options PowerupProofOptions) (PowerupProof, error) {
+	e := func(w error, ext error) (PowerupProof, error) {
+		return PowerupProof{}, fmt.Errorf("%w, %s", w, ext.Error())
+	}
+
+	options = options.withDefaults()
+	if err := options.validate(); err != nil {
+		return e(ErrInvalidProofOptions, err)
+	}
+
+	profile := options.CryptoProfile
+
+	_, rawTumblerKey, err := readKey(tumblerPrivateKey, typePrivateKey)
+	if err != nil {
+		return e(ErrInvalidTumblerKey, err)
+	}
+
+	tumblerKey, ok := rawTumblerKey.(*rsa.PrivateKey)
+	if !ok {
+		return e(ErrInvalidTumblerKey, errors.New("not an RSA private key"))
+	}
+
+	_, rawSignatureKey, err := readKey(senderSignatureKey, typePublicKey)
+	if err != nil {
+		return e(ErrInvalidSignatureKey, err)
+	}
+
+	signatureKey, ok := rawSignatureKey.(*rsa.PublicKey)
+	if !ok {
+		return e(ErrInvalidSignatureKey, errors.New("not an RSA public key"))
+	}
+
+	encryptedKey, err := base64.StdEncoding.DecodeString(msg.ProofKey)
+	if err != nil {
+		return e(ErrDecryptionFailure, err)
+	}
+
+	aesKey, err := profile.decrypt(tumblerKey, encryptedKey)
+	if err != nil {
+		return e(ErrDecryptionFailure, err)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(msg.Proof)
+	if err != nil {
+		return e(ErrDecryptionFailure, err)
+	}
+
+	proofJSON, err := aesDecrypt(aesKey, ciphertext)
+	if err != nil {
+		return PowerupProof{}, err
+	}
+
+	var proof requestPowerupProof
+	if err := codec.New().UnmarshalJSON(proofJSON, &proof); err != nil {
+		return e(ErrDecryptionFailure, err)
+	}
+
+	if !proof.SenderDid.Equals(msg.Claimant) {
+		return e(ErrInvalidProof, fmt.Errorf("proof sent by %s, request claimed by %s", proof.SenderDid, msg.Claimant))
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(proof.Signature)
+	if err != nil {
+		return e(ErrInvalidProof, err)
+	}
+
+	sigPayload := proof.SenderDid.String() + proof.PairwiseDid.String() + strconv.FormatInt(proof.Timestamp, 10)
+
+	if err := profile.verify(signatureKey, []byte(sigPayload), signature); err != nil {
+		return e(ErrInvalidProof, err)
+	}
+
+	timestamp := time.Unix(proof.Timestamp, 0)
+	now := timeProvider()
+
+	if timestamp.Before(now.Add(-options.MaxAge)) || timestamp.After(now.Add(options.MaxSkew)) {
+		return e(ErrInvalidProof, fmt.Errorf("proof created at %s, outside the accepted window", timestamp.UTC()))
+	}
+
+	return PowerupProof{
+		SenderDid:   proof.SenderDid,
+		PairwiseDid: proof.PairwiseDid,
+		Timestamp:   timestamp,
+	}, nil
+}
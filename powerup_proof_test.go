@@ -0,0 +1,132 @@
+package commercio
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func TestOpenPowerupProof(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	tumblerPrivateKey, tumblerPublicKey := testRSAKeypair(t)
+	signaturePrivateKey, signaturePublicKey := testRSAKeypair(t)
+	otherPrivateKey, otherPublicKey := testRSAKeypair(t)
+
+	pairwise := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	// build returns a power-up request built at createdAt
+	build := func(createdAt time.Time) MsgRequestDidPowerUp {
+		timeProvider = func() time.Time { return createdAt }
+		defer func() { timeProvider = time.Now }()
+
+		msg, err := sdk.BuildPowerupRequest(PowerUpParams{
+			PubKey:          sdk.PublicKey,
+			TumblerKey:      strings.NewReader(tumblerPublicKey),
+			SignatureKey:    strings.NewReader(signaturePrivateKey),
+			Amount:          42,
+			PairwiseAddress: pairwise,
+		})
+		require.NoError(t, err)
+
+		return msg
+	}
+
+	fresh := build(time.Now())
+
+	tampered := fresh
+	proof, err := base64.StdEncoding.DecodeString(tampered.Proof)
+	require.NoError(t, err)
+	proof[len(proof)-1] ^= 0xff
+	tampered.Proof = base64.StdEncoding.EncodeToString(proof)
+
+	otherClaimant := fresh
+	otherClaimant.Claimant = pairwise
+
+	tests := []struct {
+		name         string
+		msg          MsgRequestDidPowerUp
+		tumblerKey   string
+		signatureKey string
+		wantErr      error
+	}{
+		{"valid proof", fresh, tumblerPrivateKey, signaturePublicKey, nil},
+		{"almost stale proof", build(time.Now().Add(-9 * time.Minute)), tumblerPrivateKey, signaturePublicKey, nil},
+		{"stale proof", build(time.Now().Add(-11 * time.Minute)), tumblerPrivateKey, signaturePublicKey, ErrInvalidProof},
+		{"proof from the future", build(time.Now().Add(5 * time.Minute)), tumblerPrivateKey, signaturePublicKey, ErrInvalidProof},
+		{"signed by someone else", fresh, tumblerPrivateKey, otherPublicKey, ErrInvalidProof},
+		{"claimed by someone else", otherClaimant, tumblerPrivateKey, signaturePublicKey, ErrInvalidProof},
+		{"encrypted for someone else", fresh, otherPrivateKey, signaturePublicKey, ErrDecryptionFailure},
+		{"tampered proof", tampered, tumblerPrivateKey, signaturePublicKey, ErrTamperedCiphertext},
+		{"invalid tumbler key", fresh, tumblerPublicKey, signaturePublicKey, ErrInvalidTumblerKey},
+		{"invalid signature key", fresh, tumblerPrivateKey, signaturePrivateKey, ErrInvalidSignatureKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := OpenPowerupProof(tt.msg, strings.NewReader(tt.tumblerKey), strings.NewReader(tt.signatureKey))
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.wantErr), err.Error())
+				require.Equal(t, PowerupProof{}, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, sdk.Address, res.SenderDid.String())
+			require.Equal(t, pairwise, res.PairwiseDid)
+			require.WithinDuration(t, time.Now(), res.Timestamp, 10*time.Minute)
+		})
+	}
+}
+
+func TestOpenPowerupProofWithOptions(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	tumblerPrivateKey, tumblerPublicKey := testRSAKeypair(t)
+	signaturePrivateKey, signaturePublicKey := testRSAKeypair(t)
+
+	timeProvider = func() time.Time { return time.Now().Add(-30 * time.Minute) }
+	msg, err := sdk.BuildPowerupRequest(PowerUpParams{
+		PubKey:          sdk.PublicKey,
+		TumblerKey:      strings.NewReader(tumblerPublicKey),
+		SignatureKey:    strings.NewReader(signaturePrivateKey),
+		Amount:          42,
+		PairwiseAddress: types.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+	})
+	timeProvider = time.Now
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		options PowerupProofOptions
+		wantErr error
+	}{
+		{"default max age", PowerupProofOptions{}, ErrInvalidProof},
+		{"longer max age", PowerupProofOptions{MaxAge: time.Hour}, nil},
+		{"shorter max age", PowerupProofOptions{MaxAge: 20 * time.Minute}, ErrInvalidProof},
+		{"negative max age", PowerupProofOptions{MaxAge: -time.Hour}, ErrInvalidProofOptions},
+		{"unsupported crypto profile", PowerupProofOptions{CryptoProfile: CryptoProfile{Signature: "rsa"}}, ErrInvalidProofOptions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := OpenPowerupProofWithOptions(msg, strings.NewReader(tumblerPrivateKey), strings.NewReader(signaturePublicKey), tt.options)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.wantErr), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
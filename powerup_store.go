@@ -0,0 +1,223 @@
+package commercio
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// PowerUpStore keeps track of the power-up requests sent by RequestPowerUp, so that their progress can be
+// followed with PowerUpStatus, even across restarts if the store is persistent.
+// Requests are kept until they get removed, e.g. by PrunePowerUpRequests once they have been handled.
+// Implementations must be safe for concurrent use.
+type PowerUpStore interface {
+	// Add records request.
+	Add(request PowerUpRequest) error
+
+	// Get returns the request identified by requestID, and false if it hasn't been recorded.
+	Get(requestID string) (PowerUpRequest, bool, error)
+
+	// All returns all the recorded requests, in the order they have been added.
+	All() ([]PowerUpRequest, error)
+
+	// Remove forgets the requests identified by requestIDs, ignoring the ones which haven't been recorded.
+	Remove(requestIDs ...string) error
+}
+
+// MemoryPowerUpStore is a PowerUpStore keeping power-up requests in memory.
+type MemoryPowerUpStore struct {
+	mu       sync.Mutex
+	requests []PowerUpRequest
+}
+
+// NewMemoryPowerUpStore returns an empty MemoryPowerUpStore.
+func NewMemoryPowerUpStore() *MemoryPowerUpStore {
+	return &MemoryPowerUpStore{}
+}
+
+// Add implements PowerUpStore.
+func (m *MemoryPowerUpStore) Add(request PowerUpRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, request)
+	return nil
+}
+
+// Get implements PowerUpStore.
+func (m *MemoryPowerUpStore) Get(requestID string) (PowerUpRequest, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, r := range m.requests {
+		if r.ID == requestID {
+			return r, true, nil
+		}
+	}
+
+	return PowerUpRequest{}, false, nil
+}
+
+// All implements PowerUpStore.
+func (m *MemoryPowerUpStore) All() ([]PowerUpRequest, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]PowerUpRequest{}, m.requests...), nil
+}
+
+// Remove implements PowerUpStore.
+func (m *MemoryPowerUpStore) Remove(requestIDs ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	removed := make(map[string]struct{}, len(requestIDs))
+	for _, id := range requestIDs {
+		removed[id] = struct{}{}
+	}
+
+	kept := m.requests[:0]
+	for _, r := range m.requests {
+		if _, ok := removed[r.ID]; !ok {
+			kept = append(kept, r)
+		}
+	}
+
+	m.requests = kept
+	return nil
+}
+
+// powerUpRecord is a line of the file backing a FilePowerUpStore: either an added request, or the ID of a removed
+// one.
+type powerUpRecord struct {
+	Added   *PowerUpRequest `json:"added,omitempty"`
+	Removed string          `json:"removed,omitempty"`
+}
+
+// line returns r encoded as a line of the file backing a FilePowerUpStore.
+func (r powerUpRecord) line() (string, error) {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("could not encode power-up store record: %w", err)
+	}
+
+	return string(line), nil
+}
+
+// FilePowerUpStore is a PowerUpStore persisting power-up requests in a file, one JSON record per line.
+// Removed requests are dropped from the file the next time it gets opened.
+type FilePowerUpStore struct {
+	memory *MemoryPowerUpStore
+
+	mu  sync.Mutex
+	log *appendLog
+}
+
+// NewFilePowerUpStore returns a FilePowerUpStore persisting power-up requests in the file at path, loading the
+// ones already stored there.
+// The file is created if it doesn't exist.
+func NewFilePowerUpStore(path string) (*FilePowerUpStore, error) {
+	memory := NewMemoryPowerUpStore()
+	compact := false
+
+	log, err := openAppendLog(path, "power-up store file", func(line string) error {
+		var record powerUpRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			return fmt.Errorf("malformed power-up store line: %s", line)
+		}
+
+		switch {
+		case record.Added != nil:
+			_ = memory.Add(*record.Added)
+		case record.Removed != "":
+			_ = memory.Remove(record.Removed)
+			compact = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if compact {
+		lines := make([]string, len(memory.requests))
+		for i := range memory.requests {
+			record := powerUpRecord{Added: &memory.requests[i]}
+			if lines[i], err = record.line(); err != nil {
+				_ = log.close()
+				return nil, err
+			}
+		}
+
+		if err := log.rewrite(lines); err != nil {
+			_ = log.close()
+			return nil, err
+		}
+	}
+
+	return &FilePowerUpStore{
+		memory: memory,
+		log:    log,
+	}, nil
+}
+
+// Add implements PowerUpStore.
+func (f *FilePowerUpStore) Add(request PowerUpRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.write(powerUpRecord{Added: &request}); err != nil {
+		return err
+	}
+
+	return f.memory.Add(request)
+}
+
+// Get implements PowerUpStore.
+func (f *FilePowerUpStore) Get(requestID string) (PowerUpRequest, bool, error) {
+	return f.memory.Get(requestID)
+}
+
+// All implements PowerUpStore.
+func (f *FilePowerUpStore) All() ([]PowerUpRequest, error) {
+	return f.memory.All()
+}
+
+// Remove implements PowerUpStore.
+func (f *FilePowerUpStore) Remove(requestIDs ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range requestIDs {
+		if _, ok, _ := f.memory.Get(id); !ok {
+			continue
+		}
+
+		if err := f.write(powerUpRecord{Removed: id}); err != nil {
+			return err
+		}
+
+		_ = f.memory.Remove(id)
+	}
+
+	return nil
+}
+
+// write appends record to the file backing f.
+func (f *FilePowerUpStore) write(record powerUpRecord) error {
+	line, err := record.line()
+	if err != nil {
+		return err
+	}
+
+	return f.log.append(line)
+}
+
+// Close closes the file backing f.
+func (f *FilePowerUpStore) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.log.close()
+}
@@ -0,0 +1,99 @@
+package commercio
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// testPowerUpRequest returns a PowerUpRequest identified by id.
+func testPowerUpRequest(id string) PowerUpRequest {
+	return PowerUpRequest{
+		ID:              id,
+		TxHash:          "hash-" + id,
+		Amount:          types.NewCoins(types.NewInt64Coin("ucommercio", 42)),
+		PairwiseAddress: types.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+	}
+}
+
+func TestMemoryPowerUpStore(t *testing.T) {
+	store := NewMemoryPowerUpStore()
+	first, second := testPowerUpRequest("first"), testPowerUpRequest("second")
+
+	_, ok, err := store.Get("first")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, store.Add(first))
+	require.NoError(t, store.Add(second))
+
+	res, ok, err := store.Get("first")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, first, res)
+
+	require.NoError(t, store.Remove("first", "missing"))
+
+	all, err := store.All()
+	require.NoError(t, err)
+	require.Equal(t, []PowerUpRequest{second}, all)
+}
+
+func TestFilePowerUpStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "commercio-sdk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = NewFilePowerUpStore(filepath.Join(dir, "missing", "powerups"))
+	require.Error(t, err)
+
+	path := filepath.Join(dir, "powerups")
+	first, second := testPowerUpRequest("first"), testPowerUpRequest("second")
+
+	store, err := NewFilePowerUpStore(path)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Add(first))
+	require.NoError(t, store.Add(second))
+	require.NoError(t, store.Remove("first", "missing"))
+	require.NoError(t, store.Close())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 3)
+
+	// requests are loaded back when the file gets opened again, and removed ones are dropped from it
+	store, err = NewFilePowerUpStore(path)
+	require.NoError(t, err)
+
+	all, err := store.All()
+	require.NoError(t, err)
+	require.Equal(t, []PowerUpRequest{second}, all)
+
+	data, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 1)
+
+	// the compacted file can still be appended to
+	require.NoError(t, store.Add(first))
+	require.NoError(t, store.Close())
+
+	store, err = NewFilePowerUpStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	all, err = store.All()
+	require.NoError(t, err)
+	require.Equal(t, []PowerUpRequest{second, first}, all)
+
+	// malformed files are refused
+	require.NoError(t, ioutil.WriteFile(path, []byte("not json\n"), 0600))
+	_, err = NewFilePowerUpStore(path)
+	require.Error(t, err)
+}
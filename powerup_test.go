@@ -0,0 +1,234 @@
+package commercio
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// testPowerUpParams returns valid PowerUpParams requesting amount for pairwise.
+func testPowerUpParams(t *testing.T, sdk *SDK, amount uint64, pairwise types.AccAddress) PowerUpParams {
+	signaturePrivateKey, _ := testRSAKeypair(t)
+	_, tumblerKey := testRSAKeypair(t)
+
+	return PowerUpParams{
+		PubKey:          sdk.PublicKey,
+		TumblerKey:      strings.NewReader(tumblerKey),
+		SignatureKey:    strings.NewReader(signaturePrivateKey),
+		Amount:          amount,
+		PairwiseAddress: pairwise,
+	}
+}
+
+func TestSDK_RequestPowerUp(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	pairwise := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerAccountResponders(sdk, 0, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "ok!"}))
+
+	first, err := sdk.RequestPowerUp(testPowerUpParams(t, sdk, 42, pairwise))
+	require.NoError(t, err)
+	require.NotEmpty(t, first.ID)
+	require.Equal(t, "ok!", first.TxHash)
+	require.Equal(t, pairwise, first.PairwiseAddress)
+	require.Equal(t, "42", first.Amount.AmountOf("ucommercio").String())
+
+	second, err := sdk.RequestPowerUp(testPowerUpParams(t, sdk, 10, pairwise))
+	require.NoError(t, err)
+	require.NotEqual(t, first.ID, second.ID)
+
+	// invalid requests are neither sent nor recorded
+	_, err = sdk.RequestPowerUp(PowerUpParams{})
+	require.Error(t, err)
+
+	requests, err := sdk.PowerUpRequests()
+	require.NoError(t, err)
+	require.Equal(t, []PowerUpRequest{first, second}, requests)
+}
+
+func TestSDK_RequestPowerUp_broadcastFailure(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerAccountResponders(sdk, 0, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{Code: 5, Codespace: "sdk", RawLog: "insufficient funds"}))
+
+	_, err = sdk.RequestPowerUp(testPowerUpParams(t, sdk, 42, types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrBroadcast))
+
+	requests, err := sdk.PowerUpRequests()
+	require.NoError(t, err)
+	require.Empty(t, requests)
+}
+
+func TestSDK_PowerUpStatus(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	pairwise := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerAccountResponders(sdk, 0, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "ok!"}))
+
+	sent, err := sdk.RequestPowerUp(testPowerUpParams(t, sdk, 42, pairwise))
+	require.NoError(t, err)
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/bank/balances/"+pairwise.String(), lcdResponder(`[{"denom":"ucommercio","amount":"42"}]`))
+
+	tests := []struct {
+		name        string
+		id          string
+		result      string
+		want        PowerUpRequestStatus
+		wantBalance bool
+	}{
+		{
+			"pending request",
+			"unknown",
+			`{"claimant":"` + sdk.Address + `","id":"unknown"}`,
+			PowerUpRequestStatus{ID: "unknown", Status: PowerUpStatusPending},
+			false,
+		},
+		{
+			"rejected request",
+			"unknown",
+			`{"status":{"type":"Rejected","message":"invalid proof"},"claimant":"` + sdk.Address + `","id":"unknown"}`,
+			PowerUpRequestStatus{ID: "unknown", Status: PowerUpStatusRejected, Message: "invalid proof"},
+			false,
+		},
+		{
+			"approved request sent by the SDK",
+			sent.ID,
+			`{"status":{"type":"approved","message":"ok"},"claimant":"` + sdk.Address + `","id":"` + sent.ID + `"}`,
+			PowerUpRequestStatus{ID: sent.ID, Status: PowerUpStatusApproved, Message: "ok", PairwiseAddress: pairwise},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/powerUpRequest/"+tt.id, lcdResponder(tt.result))
+
+			res, err := sdk.PowerUpStatus(tt.id)
+			require.NoError(t, err)
+
+			if tt.wantBalance {
+				require.Equal(t, "42", res.PairwiseBalance.AmountOf("ucommercio").String())
+				res.PairwiseBalance = nil
+			}
+
+			require.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestSDK_PowerUpStatus_errors(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	pairwise := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerAccountResponders(sdk, 0, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "ok!"}))
+
+	sent, err := sdk.RequestPowerUp(testPowerUpParams(t, sdk, 42, pairwise))
+	require.NoError(t, err)
+
+	// missing request
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/powerUpRequest/"+sent.ID, httpmock.NewJsonResponderOrPanic(http.StatusNotFound, sacco.Error{Error: "not found"}))
+
+	_, err = sdk.PowerUpStatus(sent.ID)
+	require.True(t, errors.Is(err, ErrQuery))
+
+	// balance query failure
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/powerUpRequest/"+sent.ID, lcdResponder(`{"id":"`+sent.ID+`"}`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/bank/balances/"+pairwise.String(), httpmock.NewJsonResponderOrPanic(http.StatusInternalServerError, sacco.Error{Error: "error!"}))
+
+	res, err := sdk.PowerUpStatus(sent.ID)
+	require.True(t, errors.Is(err, ErrQuery))
+	require.Equal(t, PowerUpRequestStatus{}, res)
+}
+
+func TestSDK_PrunePowerUpRequests(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	pairwise := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerAccountResponders(sdk, 0, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "ok!"}))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/bank/balances/"+pairwise.String(), lcdResponder(`[{"denom":"ucommercio","amount":"42"}]`))
+
+	pending, err := sdk.RequestPowerUp(testPowerUpParams(t, sdk, 42, pairwise))
+	require.NoError(t, err)
+
+	approved, err := sdk.RequestPowerUp(testPowerUpParams(t, sdk, 10, pairwise))
+	require.NoError(t, err)
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/powerUpRequest/"+pending.ID, lcdResponder(`{"id":"`+pending.ID+`"}`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/powerUpRequest/"+approved.ID, lcdResponder(`{"status":{"type":"approved","message":"ok"},"id":"`+approved.ID+`"}`))
+
+	pruned, err := sdk.PrunePowerUpRequests()
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+	require.Equal(t, approved.ID, pruned[0].ID)
+	require.Equal(t, PowerUpStatusApproved, pruned[0].Status)
+
+	requests, err := sdk.PowerUpRequests()
+	require.NoError(t, err)
+	require.Equal(t, []PowerUpRequest{pending}, requests)
+
+}
+
+func TestSDK_PrunePowerUpRequests_missingRequest(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	pairwise := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerAccountResponders(sdk, 0, httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "ok!"}))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/bank/balances/"+pairwise.String(), lcdResponder(`[{"denom":"ucommercio","amount":"42"}]`))
+
+	// the transaction of the first request failed after being accepted by the LCD, so it never reached the chain
+	missing, err := sdk.RequestPowerUp(testPowerUpParams(t, sdk, 42, pairwise))
+	require.NoError(t, err)
+
+	approved, err := sdk.RequestPowerUp(testPowerUpParams(t, sdk, 10, pairwise))
+	require.NoError(t, err)
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/powerUpRequest/"+missing.ID, httpmock.NewJsonResponderOrPanic(http.StatusNotFound, sacco.Error{Error: "not found"}))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/powerUpRequest/"+approved.ID, lcdResponder(`{"status":{"type":"approved","message":"ok"},"id":"`+approved.ID+`"}`))
+
+	pruned, err := sdk.PrunePowerUpRequests()
+	require.True(t, errors.Is(err, ErrQuery))
+	require.Contains(t, err.Error(), missing.ID)
+	require.Len(t, pruned, 1)
+	require.Equal(t, approved.ID, pruned[0].ID)
+
+	requests, err := sdk.PowerUpRequests()
+	require.NoError(t, err)
+	require.Equal(t, []PowerUpRequest{missing}, requests)
+}
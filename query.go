@@ -0,0 +1,202 @@
+package commercio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// lcdResponse represents the enclosure the LCD puts around each query result.
+type lcdResponse struct {
+	Height string          `json:"height"`
+	Result json.RawMessage `json:"result"`
+}
+
+// identityResponse is the LCD response to a /identities/{address} query.
+type identityResponse struct {
+	Owner       types.AccAddress `json:"owner"`
+	DidDocument *DidDocument     `json:"did_document"`
+}
+
+// membershipResponse is the LCD response to a /membership/{address} query.
+type membershipResponse struct {
+	User           types.AccAddress `json:"user"`
+	MembershipType string           `json:"membership_type"`
+}
+
+// query performs a GET request on path against the pre-defined LCD, then decodes its result in dest by using the
+// commercio.network app codec.
+func (sdk *SDK) query(path string, dest interface{}) error {
+	endpoint := fmt.Sprintf("%s%s", sdk.config.LCDEndpoint, path)
+
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		return fmt.Errorf("%w, %s", ErrQuery, err.Error())
+	}
+
+	defer resp.Body.Close()
+
+	jdec := json.NewDecoder(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return lcdError(jdec, resp.StatusCode)
+	}
+
+	var lr lcdResponse
+	if err := jdec.Decode(&lr); err != nil {
+		return fmt.Errorf("%w, %s", ErrQuery, err.Error())
+	}
+
+	if err := sdk.codec.UnmarshalJSON(lr.Result, dest); err != nil {
+		return fmt.Errorf("%w, %s", ErrQuery, err.Error())
+	}
+
+	return nil
+}
+
+// Identity returns the DidDocument associated to address.
+func (sdk *SDK) Identity(address types.AccAddress) (DidDocument, error) {
+	var ir identityResponse
+	if err := sdk.query(fmt.Sprintf("/identities/%s", address), &ir); err != nil {
+		return DidDocument{}, err
+	}
+
+	if ir.DidDocument == nil {
+		return DidDocument{}, fmt.Errorf("%w, no did document associated to %s", ErrQuery, address)
+	}
+
+	return *ir.DidDocument, nil
+}
+
+// SentDocuments returns all the Documents sent by address.
+func (sdk *SDK) SentDocuments(address types.AccAddress) ([]Document, error) {
+	var docs []Document
+	if err := sdk.query(fmt.Sprintf("/docs/%s/sent", address), &docs); err != nil {
+		return nil, err
+	}
+
+	return docs, nil
+}
+
+// ReceivedDocuments returns all the Documents received by address.
+func (sdk *SDK) ReceivedDocuments(address types.AccAddress) ([]Document, error) {
+	var docs []Document
+	if err := sdk.query(fmt.Sprintf("/docs/%s/received", address), &docs); err != nil {
+		return nil, err
+	}
+
+	return docs, nil
+}
+
+// SentReceipts returns all the DocumentReceipts sent by address.
+func (sdk *SDK) SentReceipts(address types.AccAddress) ([]DocumentReceipt, error) {
+	var receipts []DocumentReceipt
+	if err := sdk.query(fmt.Sprintf("/receipts/%s/sent", address), &receipts); err != nil {
+		return nil, err
+	}
+
+	return receipts, nil
+}
+
+// ReceivedReceipts returns all the DocumentReceipts received by address.
+func (sdk *SDK) ReceivedReceipts(address types.AccAddress) ([]DocumentReceipt, error) {
+	var receipts []DocumentReceipt
+	if err := sdk.query(fmt.Sprintf("/receipts/%s/received", address), &receipts); err != nil {
+		return nil, err
+	}
+
+	return receipts, nil
+}
+
+// Membership returns the membership type bought by address, which is one of the MembershipType* values.
+func (sdk *SDK) Membership(address types.AccAddress) (string, error) {
+	var mr membershipResponse
+	if err := sdk.query(fmt.Sprintf("/membership/%s", address), &mr); err != nil {
+		return "", err
+	}
+
+	return mr.MembershipType, nil
+}
+
+// Cdps returns all the collateralized debt positions opened by owner.
+func (sdk *SDK) Cdps(owner types.AccAddress) ([]Position, error) {
+	var positions []Position
+	if err := sdk.query(fmt.Sprintf("/commerciomint/cdps/%s", owner), &positions); err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+// Balance returns the coins owned by address.
+func (sdk *SDK) Balance(address types.AccAddress) (types.Coins, error) {
+	var coins types.Coins
+	if err := sdk.query(fmt.Sprintf("/bank/balances/%s", address), &coins); err != nil {
+		return nil, err
+	}
+
+	return coins, nil
+}
+
+// lcdError decodes the error sent by the LCD with status code through jdec.
+func lcdError(jdec *json.Decoder, status int) error {
+	var jerr sacco.Error
+	if err := jdec.Decode(&jerr); err != nil {
+		return fmt.Errorf("%w, could not decode error response with status %d: %s", ErrQuery, status, err.Error())
+	}
+
+	return fmt.Errorf("%w, %s", ErrQuery, jerr.Error)
+}
+
+// nodeInfo returns the informations of the node the pre-defined LCD is connected to, like its chain ID.
+func (sdk *SDK) nodeInfo() (sacco.NodeInfo, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/node_info", sdk.config.LCDEndpoint))
+	if err != nil {
+		return sacco.NodeInfo{}, fmt.Errorf("%w, %s", ErrQuery, err.Error())
+	}
+
+	defer resp.Body.Close()
+
+	jdec := json.NewDecoder(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return sacco.NodeInfo{}, lcdError(jdec, resp.StatusCode)
+	}
+
+	var ni sacco.NodeInfo
+	if err := jdec.Decode(&ni); err != nil {
+		return sacco.NodeInfo{}, fmt.Errorf("%w, %s", ErrQuery, err.Error())
+	}
+
+	return ni, nil
+}
+
+// accountData returns account number and sequence of address.
+func (sdk *SDK) accountData(address string) (sacco.AccountDataValue, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/auth/accounts/%s", sdk.config.LCDEndpoint, address))
+	if err != nil {
+		return sacco.AccountDataValue{}, fmt.Errorf("%w, %s", ErrQuery, err.Error())
+	}
+
+	defer resp.Body.Close()
+
+	jdec := json.NewDecoder(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return sacco.AccountDataValue{}, lcdError(jdec, resp.StatusCode)
+	}
+
+	var ad sacco.AccountData
+	if err := jdec.Decode(&ad); err != nil {
+		return sacco.AccountDataValue{}, fmt.Errorf("%w, %s", ErrQuery, err.Error())
+	}
+
+	if ad.Result.Value.Address == "" {
+		return sacco.AccountDataValue{}, fmt.Errorf("%w, account with address %s does not exist", ErrQuery, address)
+	}
+
+	return ad.Result.Value, nil
+}
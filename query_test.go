@@ -0,0 +1,229 @@
+package commercio
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+const testAddress = "did:com:1rv8jkqulyf5j55pcjte7v8fg6h0gxcerw8a042"
+
+func lcdResponder(result string) httpmock.Responder {
+	return httpmock.NewStringResponder(http.StatusOK, `{"height":"42","result":`+result+`}`)
+}
+
+func TestSDK_query(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		responder httpmock.Responder
+		want      string
+		wantErr   bool
+	}{
+		{
+			"error from the LCD endpoint",
+			httpmock.NewJsonResponderOrPanic(http.StatusNotFound, sacco.Error{Error: "error!"}),
+			"",
+			true,
+		},
+		{
+			"error from the LCD endpoint with malformed body",
+			httpmock.NewStringResponder(http.StatusInternalServerError, "aaa"),
+			"",
+			true,
+		},
+		{
+			"malformed response",
+			httpmock.NewStringResponder(http.StatusOK, "aaa"),
+			"",
+			true,
+		},
+		{
+			"result is not of the expected type",
+			lcdResponder(`42`),
+			"",
+			true,
+		},
+		{
+			"everything is fine",
+			lcdResponder(`"hello"`),
+			"hello",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/path", tt.responder)
+
+			var res string
+			err := sdk.query("/path", &res)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrQuery))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestSDK_Queries(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	addr, err := Address(testAddress)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		path   string
+		result string
+		check  func(t *testing.T) error
+	}{
+		{
+			"identity",
+			"/identities/" + testAddress,
+			`{"owner":"` + testAddress + `","did_document":{"@context":"https://www.w3.org/ns/did/v1","id":"` + testAddress + `"}}`,
+			func(t *testing.T) error {
+				res, err := sdk.Identity(addr)
+				if err == nil {
+					require.Equal(t, addr, res.ID)
+				}
+				return err
+			},
+		},
+		{
+			"sent documents",
+			"/docs/" + testAddress + "/sent",
+			`[{"sender":"` + testAddress + `","uuid":"6a2f41a3-c54c-fce8-32d2-0324e1c32e22"}]`,
+			func(t *testing.T) error {
+				res, err := sdk.SentDocuments(addr)
+				if err == nil {
+					require.Len(t, res, 1)
+					require.Equal(t, "6a2f41a3-c54c-fce8-32d2-0324e1c32e22", res[0].UUID)
+				}
+				return err
+			},
+		},
+		{
+			"received documents",
+			"/docs/" + testAddress + "/received",
+			`[{"sender":"` + testAddress + `","uuid":"6a2f41a3-c54c-fce8-32d2-0324e1c32e22"}]`,
+			func(t *testing.T) error {
+				res, err := sdk.ReceivedDocuments(addr)
+				if err == nil {
+					require.Len(t, res, 1)
+					require.Equal(t, addr, res[0].Sender)
+				}
+				return err
+			},
+		},
+		{
+			"sent receipts",
+			"/receipts/" + testAddress + "/sent",
+			`[{"uuid":"6a2f41a3-c54c-fce8-32d2-0324e1c32e22","tx_hash":"hash"}]`,
+			func(t *testing.T) error {
+				res, err := sdk.SentReceipts(addr)
+				if err == nil {
+					require.Len(t, res, 1)
+					require.Equal(t, "hash", res[0].TxHash)
+				}
+				return err
+			},
+		},
+		{
+			"received receipts",
+			"/receipts/" + testAddress + "/received",
+			`[]`,
+			func(t *testing.T) error {
+				res, err := sdk.ReceivedReceipts(addr)
+				if err == nil {
+					require.Empty(t, res)
+				}
+				return err
+			},
+		},
+		{
+			"membership",
+			"/membership/" + testAddress,
+			`{"user":"` + testAddress + `","membership_type":"gold"}`,
+			func(t *testing.T) error {
+				res, err := sdk.Membership(addr)
+				if err == nil {
+					require.Equal(t, MembershipTypeGold, res)
+				}
+				return err
+			},
+		},
+		{
+			"cdps",
+			"/commerciomint/cdps/" + testAddress,
+			`[{"owner":"` + testAddress + `","deposit":[{"denom":"ucommercio","amount":"100"}],"credits":{"denom":"uccc","amount":"50"},"timestamp":"10"}]`,
+			func(t *testing.T) error {
+				res, err := sdk.Cdps(addr)
+				if err == nil {
+					require.Len(t, res, 1)
+					require.Equal(t, int64(10), res[0].CreatedAt)
+				}
+				return err
+			},
+		},
+		{
+			"balance",
+			"/bank/balances/" + testAddress,
+			`[{"denom":"ucommercio","amount":"100"}]`,
+			func(t *testing.T) error {
+				res, err := sdk.Balance(addr)
+				if err == nil {
+					require.Equal(t, int64(100), res.AmountOf("ucommercio").Int64())
+				}
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317"+tt.path, lcdResponder(tt.result))
+			require.NoError(t, tt.check(t))
+		})
+
+		t.Run(tt.name+" with LCD error", func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317"+tt.path, httpmock.NewJsonResponderOrPanic(http.StatusNotFound, sacco.Error{Error: "error!"}))
+			require.Error(t, tt.check(t))
+		})
+	}
+}
+
+func TestSDK_Identity_missingDidDocument(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	addr, err := Address(testAddress)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/identities/"+testAddress, lcdResponder(`{"owner":"`+testAddress+`"}`))
+
+	_, err = sdk.Identity(addr)
+	require.Error(t, err)
+}
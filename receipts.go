@@ -0,0 +1,103 @@
+package commercio
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/commercionetwork/commercionetwork/x/docs"
+	"github.com/cosmos/cosmos-sdk/types"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ReceivedDocument is a Document received by the account associated to an SDK, along with the hash of the
+// transaction which shared it.
+type ReceivedDocument struct {
+	// Document is the received document.
+	Document Document
+
+	// TxHash is the hash of the transaction which shared Document.
+	TxHash string
+
+	// Proof is the optional proof that Document has been read.
+	Proof string
+}
+
+// BuildReceiptFor creates a MsgSendDocumentReceipt acknowledging doc, shared with the account associated to sdk in
+// the transaction identified by txHash.
+// proof is the optional proof that doc has been read.
+func (sdk *SDK) BuildReceiptFor(doc Document, txHash string, proof string) (MsgSendDocumentReceipt, error) {
+	e := func(w error, ext error) (MsgSendDocumentReceipt, error) {
+		return MsgSendDocumentReceipt{}, fmt.Errorf("%w, %s", w, ext.Error())
+	}
+
+	sender, err := types.AccAddressFromBech32(sdk.Address)
+	if err != nil {
+		return e(ErrInvalidAddress, err)
+	}
+
+	if !doc.Recipients.Contains(sender) {
+		return e(ErrNotRecipient, fmt.Errorf("document %s", doc.UUID))
+	}
+
+	receipt := MsgSendDocumentReceipt{
+		UUID:         uuid.NewV4().String(),
+		Sender:       sender,
+		Recipient:    doc.Sender,
+		TxHash:       txHash,
+		DocumentUUID: doc.UUID,
+		Proof:        proof,
+	}
+
+	if err := docs.MsgSendDocumentReceipt(receipt).ValidateBasic(); err != nil {
+		return e(ErrInvalidReceipt, err)
+	}
+
+	return receipt, nil
+}
+
+// AcknowledgeDocuments sends a MsgSendDocumentReceipt for each document in received, batching them in as few
+// transactions as allowed by the SDK FeePolicy MaxGas, then returns the hashes of the transactions sent.
+// If the FeePolicy requires simulation, batches are sized after the simulated gas of the first receipt.
+// No transaction is sent if any receipt cannot be built; if a transaction fails, the hashes of the ones sent before
+// it are returned along with the error.
+func (sdk *SDK) AcknowledgeDocuments(received ...ReceivedDocument) ([]string, error) {
+	if len(received) == 0 {
+		return nil, fmt.Errorf("%w, %s", ErrInvalidReceipt, errors.New("no document provided"))
+	}
+
+	msgs := make([]interface{}, len(received))
+	for i, r := range received {
+		receipt, err := sdk.BuildReceiptFor(r.Document, r.TxHash, r.Proof)
+		if err != nil {
+			return nil, fmt.Errorf("document #%d: %w", i, err)
+		}
+
+		msgs[i] = receipt
+	}
+
+	batchSize, err := sdk.batchSize(msgs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if batchSize == 0 {
+		batchSize = len(msgs)
+	}
+
+	var hashes []string
+	for start := 0; start < len(msgs); start += batchSize {
+		end := start + batchSize
+		if end > len(msgs) {
+			end = len(msgs)
+		}
+
+		hash, err := sdk.sendBatch(msgs[start:end]...)
+		if err != nil {
+			return hashes, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
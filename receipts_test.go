@@ -0,0 +1,216 @@
+package commercio
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/jarcoal/httpmock"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// receivedDocument returns a Document sent from sender to recipient.
+func receivedDocument(sender, recipient types.AccAddress) Document {
+	return Document{
+		Sender:     sender,
+		Recipients: []types.AccAddress{recipient},
+		UUID:       uuid.NewV4().String(),
+	}
+}
+
+func TestSDK_BuildReceiptFor(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	self, err := Address(sdk.Address)
+	require.NoError(t, err)
+
+	other := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	tests := []struct {
+		name    string
+		doc     Document
+		txHash  string
+		wantErr error
+	}{
+		{
+			"not a recipient",
+			receivedDocument(self, other),
+			"hash",
+			ErrNotRecipient,
+		},
+		{
+			"missing tx hash",
+			receivedDocument(other, self),
+			"",
+			ErrInvalidReceipt,
+		},
+		{
+			"invalid document UUID",
+			Document{Sender: other, Recipients: []types.AccAddress{self}, UUID: "uuid"},
+			"hash",
+			ErrInvalidReceipt,
+		},
+		{
+			"all ok",
+			receivedDocument(other, self),
+			"hash",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := sdk.BuildReceiptFor(tt.doc, tt.txHash, "proof")
+
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr))
+				require.Equal(t, MsgSendDocumentReceipt{}, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, self, res.Sender)
+			require.Equal(t, other, res.Recipient)
+			require.Equal(t, tt.doc.UUID, res.DocumentUUID)
+			require.Equal(t, "hash", res.TxHash)
+			require.Equal(t, "proof", res.Proof)
+		})
+	}
+}
+
+func TestSDK_AcknowledgeDocuments(t *testing.T) {
+	config := DefaultSDKConfig
+	config.FeePolicy.GasPerMsg = 100000
+	config.FeePolicy.MaxGas = 2 * config.FeePolicy.GasPerMsg
+
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", config)
+	require.NoError(t, err)
+
+	self, err := Address(sdk.Address)
+	require.NoError(t, err)
+
+	other := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var batches []int
+	registerAccountResponders(sdk, 10, func(req *http.Request) (*http.Response, error) {
+		var body sacco.TxBody
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+
+		batches = append(batches, len(body.Tx.Message))
+		return httpmock.NewJsonResponse(http.StatusOK, sacco.TxResponse{TxHash: "ok!"})
+	})
+
+	_, err = sdk.AcknowledgeDocuments()
+	require.True(t, errors.Is(err, ErrInvalidReceipt))
+
+	received := make([]ReceivedDocument, 5)
+	for i := range received {
+		received[i] = ReceivedDocument{Document: receivedDocument(other, self), TxHash: "hash"}
+	}
+
+	// no transaction is sent if any receipt is invalid
+	_, err = sdk.AcknowledgeDocuments(append(received, ReceivedDocument{Document: receivedDocument(other, self)})...)
+	require.True(t, errors.Is(err, ErrInvalidReceipt))
+	require.Empty(t, batches)
+
+	res, err := sdk.AcknowledgeDocuments(received...)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ok!", "ok!", "ok!"}, res)
+	require.Equal(t, []int{2, 2, 1}, batches)
+}
+
+func TestSDK_AcknowledgeDocuments_simulate(t *testing.T) {
+	config := DefaultSDKConfig
+	config.FeePolicy.Simulate = true
+	config.FeePolicy.MaxGas = 200000
+
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", config)
+	require.NoError(t, err)
+
+	self, err := Address(sdk.Address)
+	require.NoError(t, err)
+
+	other := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var batches []int
+	registerAccountResponders(sdk, 10, func(req *http.Request) (*http.Response, error) {
+		var body sacco.TxBody
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+
+		batches = append(batches, len(body.Tx.Message))
+		return httpmock.NewJsonResponse(http.StatusOK, sacco.TxResponse{TxHash: "ok!"})
+	})
+
+	received := make([]ReceivedDocument, 5)
+	for i := range received {
+		received[i] = ReceivedDocument{Document: receivedDocument(other, self), TxHash: "hash"}
+	}
+
+	// no transaction is sent if the batch size cannot be estimated
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:26657/abci_query", httpmock.NewStringResponder(http.StatusInternalServerError, "aaa"))
+
+	_, err = sdk.AcknowledgeDocuments(received...)
+	require.True(t, errors.Is(err, ErrSimulation))
+	require.Empty(t, batches)
+
+	// each receipt needs 60000 gas, 90000 once adjusted
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:26657/abci_query", simulationResponder(60000))
+
+	res, err := sdk.AcknowledgeDocuments(received...)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ok!", "ok!", "ok!"}, res)
+	require.Equal(t, []int{2, 2, 1}, batches)
+}
+
+func TestSDK_AcknowledgeDocuments_defaultFeePolicy(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	self, err := Address(sdk.Address)
+	require.NoError(t, err)
+
+	other := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var batches []int
+	var gas []string
+	registerAccountResponders(sdk, 10, func(req *http.Request) (*http.Response, error) {
+		var body sacco.TxBody
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+
+		batches = append(batches, len(body.Tx.Message))
+		gas = append(gas, body.Tx.Fee.Gas)
+		return httpmock.NewJsonResponse(http.StatusOK, sacco.TxResponse{TxHash: "ok!"})
+	})
+
+	received := make([]ReceivedDocument, 12)
+	for i := range received {
+		received[i] = ReceivedDocument{Document: receivedDocument(other, self), TxHash: "hash"}
+	}
+
+	// each receipt gets the default gas limit of a whole transaction, up to the default max gas
+	res, err := sdk.AcknowledgeDocuments(received...)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ok!", "ok!"}, res)
+	require.Equal(t, []int{10, 2}, batches)
+	require.Equal(t, []string{"2000000", "400000"}, gas)
+}
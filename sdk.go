@@ -5,12 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/commercionetwork/commercionetwork/app"
 	"github.com/commercionetwork/sacco.go"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
@@ -27,7 +28,9 @@ var (
 		DerivationPath: derivationPath,
 		hrp:            hrp,
 		LCDEndpoint:    "http://localhost:1317",
+		RPCEndpoint:    "http://localhost:26657",
 		Mode:           TxModeSync,
+		FeePolicy:      DefaultFeePolicy,
 	}
 )
 
@@ -63,8 +66,36 @@ type SDKConfig struct {
 	// LCDEndpoint is the commercio.network REST LCD server endpoint, where transaction will be broadcasted.
 	LCDEndpoint string
 
+	// RPCEndpoint is the Tendermint RPC server endpoint, used to simulate transactions and by SubscribeIncoming.
+	// It can be left empty if neither transaction simulation nor subscriptions are needed.
+	RPCEndpoint string
+
 	// Mode is the TxMode to be used while performing transaction-related operations.
 	Mode TxMode
+
+	// FeePolicy defines fee and gas limit attached to each transaction, DefaultFeePolicy if empty.
+	FeePolicy FeePolicy
+
+	// ChainID is the ID of the chain the SDK is expected to talk to.
+	// When set, the SDK refuses to sign transactions for any other chain; when empty, the chain ID is fetched from
+	// the LCD.
+	ChainID string
+
+	// VerifyChainID, when true, makes NewSDK check that the LCD is connected to the ChainID chain.
+	VerifyChainID bool
+
+	// TumblerKeyTTL is how long the tumbler key fetched by TumblerKey is cached.
+	// When zero, it's cached for one hour.
+	TumblerKeyTTL time.Duration
+
+	// CryptoProfile selects the RSA key size and schemes used by the SDK, its empty fields are set as in
+	// DefaultCryptoProfile.
+	CryptoProfile CryptoProfile
+
+	// PowerUpStore records the power-up requests sent by RequestPowerUp.
+	// When nil, each SDK records them in its own MemoryPowerUpStore; when set, the store is shared by all the SDKs
+	// using the config.
+	PowerUpStore PowerUpStore
 }
 
 // validate checks that each and every field of sc are complying with the specification (no empty fields).
@@ -82,19 +113,48 @@ func (sc SDKConfig) validate() error {
 		return errors.New("malformed LCD endpoint")
 	}
 
+	if sc.RPCEndpoint != "" {
+		_, err := url.Parse(sc.RPCEndpoint)
+		if err != nil || !(strings.HasPrefix(sc.RPCEndpoint, "http://") || strings.HasPrefix(sc.RPCEndpoint, "https://")) {
+			return errors.New("malformed RPC endpoint")
+		}
+	}
+
 	if sc.Mode != TxModeSync && sc.Mode != TxModeAsync && sc.Mode != TxModeBlock {
 		return errors.New("invalid transaction mode")
 	}
 
+	if err := sc.FeePolicy.withDefaults().validate(); err != nil {
+		return fmt.Errorf("invalid fee policy: %w", err)
+	}
+
+	if sc.FeePolicy.Simulate && sc.RPCEndpoint == "" {
+		return errors.New("missing RPC endpoint, needed to simulate transactions")
+	}
+
+	if sc.VerifyChainID && sc.ChainID == "" {
+		return errors.New("missing chain ID, needed to verify it")
+	}
+
+	if sc.TumblerKeyTTL < 0 {
+		return errors.New("negative tumbler key TTL")
+	}
+
+	if err := sc.CryptoProfile.validate(); err != nil {
+		return fmt.Errorf("invalid crypto profile: %w", err)
+	}
+
 	return nil
 }
 
 // SDK represents the entrypoint for the commercio.network SDK.
 type SDK struct {
-	wallet      *sacco.Wallet
+	signer      Signer
 	config      SDKConfig
 	typeMapping typeMapping
 	codec       *codec.Codec
+	account     *accountState
+	tumbler     *tumblerKeyCache
 
 	Address   string
 	PublicKey string
@@ -106,34 +166,102 @@ func NewSDK(mnemonic string, config SDKConfig) (*SDK, error) {
 		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
 	}
 
+	signer, err := NewMnemonicSigner(mnemonic, config.DerivationPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	return NewSDKWithSigner(signer, config)
+}
+
+// NewSDKWithSigner returns a new instance of SDK which signs with signer, initialized by given config.
+// config.DerivationPath is not used, since signer already holds the account keys.
+func NewSDKWithSigner(signer Signer, config SDKConfig) (*SDK, error) {
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
+	}
+
+	if signer == nil {
+		return nil, fmt.Errorf("%w, %s", ErrNewSDK, "missing signer")
+	}
+
 	config.hrp = hrp
 
-	w, err := sacco.FromMnemonic(config.hrp, mnemonic, config.DerivationPath)
+	appCodec := app.MakeCodec()
+
+	sdk, err := newSDK(signer, config, appCodec, generateTypeMappings(appCodec))
 	if err != nil {
 		return nil, fmt.Errorf("%w, %s", ErrNewSDK, err.Error())
 	}
 
-	appCodec := app.MakeCodec()
+	if config.VerifyChainID {
+		if err := sdk.verifyChainID(); err != nil {
+			return nil, wrap(ErrNewSDK, err)
+		}
+	}
+
+	return sdk, nil
+}
+
+// newSDK returns a new instance of SDK which signs with signer, using appCodec and tm to encode messages.
+func newSDK(signer Signer, config SDKConfig, appCodec *codec.Codec, tm typeMapping) (*SDK, error) {
+	pk, err := signerPublicKey(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	pkb32, err := types.Bech32ifyPubKey(types.Bech32PubKeyTypeAccPub, pk)
+	if err != nil {
+		return nil, err
+	}
+
+	config.FeePolicy = config.FeePolicy.withDefaults()
+
+	if config.PowerUpStore == nil {
+		config.PowerUpStore = NewMemoryPowerUpStore()
+	}
 
 	return &SDK{
-		wallet:      w,
+		signer:      signer,
 		config:      config,
-		typeMapping: generateTypeMappings(appCodec),
-		Address:     w.Address,
-		PublicKey:   w.PublicKeyBech32,
+		typeMapping: tm,
+		Address:     signer.Address(),
+		PublicKey:   pkb32,
 		codec:       appCodec,
+		account:     &accountState{},
+		tumbler:     &tumblerKeyCache{},
 	}, nil
 }
 
 // SendTransaction sends all the messages contained in rawMsgs through the pre-defined LCD, then returns the transaction
 // hash.
 func (sdk *SDK) SendTransaction(rawMsgs ...interface{}) (string, error) {
-	txp, err := sdk.genTx(rawMsgs...)
+	txp, err := sdk.prepareTx(rawMsgs...)
 	if err != nil {
 		return "", err
 	}
 
-	return sdk.wallet.SignAndBroadcast(txp, sdk.config.LCDEndpoint, sdk.config.Mode.asSaccoMode())
+	return sdk.signAndBroadcast(txp)
+}
+
+// prepareTx generates a transaction containing rawMsgs, and simulates it to compute its gas limit if the FeePolicy
+// requires it.
+func (sdk *SDK) prepareTx(rawMsgs ...interface{}) (sacco.TransactionPayload, error) {
+	txp, err := sdk.genTx(rawMsgs...)
+	if err != nil {
+		return sacco.TransactionPayload{}, err
+	}
+
+	if sdk.config.FeePolicy.Simulate {
+		gasUsed, err := sdk.simulate(txp)
+		if err != nil {
+			return sacco.TransactionPayload{}, err
+		}
+
+		txp.Fee = sdk.config.FeePolicy.withGas(txp.Fee, adjustedGas(gasUsed, sdk.config.FeePolicy.GasAdjustment))
+	}
+
+	return txp, nil
 }
 
 func (sdk *SDK) genTx(rawMsgs ...interface{}) (sacco.TransactionPayload, error) {
@@ -142,6 +270,7 @@ func (sdk *SDK) genTx(rawMsgs ...interface{}) (sacco.TransactionPayload, error)
 	}
 
 	msgs := make([]json.RawMessage, len(rawMsgs))
+	msgTypes := make([]string, len(rawMsgs))
 
 	for i := 0; i < len(rawMsgs); i++ {
 		aminoEncodedMsg, err := sdk.codec.MarshalJSON(rawMsgs[i])
@@ -149,8 +278,10 @@ func (sdk *SDK) genTx(rawMsgs ...interface{}) (sacco.TransactionPayload, error)
 			return sacco.TransactionPayload{}, fmt.Errorf("%w, message #%d: %s", ErrInvalidMessage, i, err.Error())
 		}
 
+		msgTypes[i] = sdk.typeMapping.cosmosType(rawMsgs[i])
+
 		enclosure := messageEnclosure{
-			Type:  sdk.typeMapping.cosmosType(rawMsgs[i]),
+			Type:  msgTypes[i],
 			Value: aminoEncodedMsg,
 		}
 
@@ -160,19 +291,8 @@ func (sdk *SDK) genTx(rawMsgs ...interface{}) (sacco.TransactionPayload, error)
 		}
 	}
 
-	feeAmount := 10000 * len(msgs)
-	feeObj := sacco.Coin{
-		Denom:  "ucommercio",
-		Amount: strconv.FormatInt(int64(feeAmount), 10),
-	}
-
-	fee := sacco.Fee{
-		Amount: []sacco.Coin{feeObj},
-		Gas:    "200000",
-	}
-
 	return sacco.TransactionPayload{
 		Message: msgs,
-		Fee:     fee,
+		Fee:     sdk.config.FeePolicy.fee(msgTypes),
 	}, nil
 }
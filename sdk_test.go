@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/commercionetwork/sacco.go"
 	"github.com/jarcoal/httpmock"
@@ -41,6 +42,15 @@ func TestSDKConfig_validate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"rpc url doesn't begin with http:// or https://",
+			SDKConfig{
+				DerivationPath: sacco.CosmosDerivationPath,
+				LCDEndpoint:    "http://aaa.com",
+				RPCEndpoint:    "aaa.com",
+			},
+			true,
+		},
 		{
 			"missing tx mode",
 			SDKConfig{
@@ -58,6 +68,63 @@ func TestSDKConfig_validate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"simulation enabled without rpc endpoint",
+			SDKConfig{
+				DerivationPath: sacco.CosmosDerivationPath,
+				LCDEndpoint:    "http://aaa.com",
+				Mode:           TxModeSync,
+				FeePolicy: FeePolicy{
+					Mode:          FeeModeFixed,
+					Denom:         "ucommercio",
+					Gas:           200000,
+					Simulate:      true,
+					GasAdjustment: 1.5,
+				},
+			},
+			true,
+		},
+		{
+			"missing fee policy falls back to the default one",
+			SDKConfig{
+				DerivationPath: sacco.CosmosDerivationPath,
+				LCDEndpoint:    "http://aaa.com",
+				Mode:           TxModeSync,
+			},
+			false,
+		},
+		{
+			"invalid fee policy",
+			SDKConfig{
+				DerivationPath: sacco.CosmosDerivationPath,
+				LCDEndpoint:    "http://aaa.com",
+				Mode:           TxModeSync,
+				FeePolicy:      FeePolicy{Mode: FeeModeFixed, Gas: 200000},
+			},
+			true,
+		},
+		{
+			"chain id verification without chain id",
+			SDKConfig{
+				DerivationPath: sacco.CosmosDerivationPath,
+				LCDEndpoint:    "http://aaa.com",
+				Mode:           TxModeSync,
+				FeePolicy:      DefaultFeePolicy,
+				VerifyChainID:  true,
+			},
+			true,
+		},
+		{
+			"negative tumbler key TTL",
+			SDKConfig{
+				DerivationPath: sacco.CosmosDerivationPath,
+				LCDEndpoint:    "http://aaa.com",
+				Mode:           TxModeSync,
+				FeePolicy:      DefaultFeePolicy,
+				TumblerKeyTTL:  -time.Second,
+			},
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -96,6 +163,16 @@ func TestNewSDK(t *testing.T) {
 			DefaultSDKConfig,
 			false,
 		},
+		{
+			"config without fee policy",
+			"first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus",
+			SDKConfig{
+				DerivationPath: DefaultSDKConfig.DerivationPath,
+				LCDEndpoint:    DefaultSDKConfig.LCDEndpoint,
+				Mode:           DefaultSDKConfig.Mode,
+			},
+			false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -109,6 +186,7 @@ func TestNewSDK(t *testing.T) {
 
 			require.NoError(t, err)
 			require.NotNil(t, res)
+			require.Equal(t, DefaultFeePolicy, res.config.FeePolicy)
 		})
 	}
 }
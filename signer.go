@@ -0,0 +1,113 @@
+package commercio
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/commercionetwork/sacco.go"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/valyala/fastjson"
+)
+
+// Signer holds the keys of a commercio.network account, and signs data on its behalf.
+// Implementations can keep the private key anywhere, e.g. in a KMS or in a local signing daemon.
+type Signer interface {
+	// Address returns the bech32-encoded address of the account.
+	Address() string
+
+	// PublicKey returns the secp256k1 public key of the account.
+	PublicKey() crypto.PubKey
+
+	// Sign signs the SHA-256 hash of data with the account secp256k1 private key, and returns the signature
+	// serialized as the 32 bytes of R followed by the 32 bytes of S.
+	Sign(data []byte) ([]byte, error)
+}
+
+// privateKeySigner is a Signer holding a secp256k1 private key in memory.
+type privateKeySigner struct {
+	key       *btcec.PrivateKey
+	publicKey secp256k1.PubKeySecp256k1
+}
+
+// newPrivateKeySigner returns a privateKeySigner for key.
+func newPrivateKeySigner(key *btcec.PrivateKey) privateKeySigner {
+	var pk secp256k1.PubKeySecp256k1
+	copy(pk[:], key.PubKey().SerializeCompressed())
+
+	return privateKeySigner{
+		key:       key,
+		publicKey: pk,
+	}
+}
+
+// NewMnemonicSigner returns a Signer for the account derived from mnemonic along derivationPath.
+func NewMnemonicSigner(mnemonic, derivationPath string) (Signer, error) {
+	w, err := sacco.FromMnemonic(hrp, mnemonic, derivationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	wex, err := w.ExportWithPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	kc, err := hdkeychain.NewKeyFromString(fastjson.GetString([]byte(wex), "private_key"))
+	if err != nil {
+		return nil, err
+	}
+
+	ec, err := kc.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return newPrivateKeySigner(ec), nil
+}
+
+func (s privateKeySigner) Address() string {
+	return types.AccAddress(s.publicKey.Address()).String()
+}
+
+func (s privateKeySigner) PublicKey() crypto.PubKey {
+	return s.publicKey
+}
+
+func (s privateKeySigner) Sign(data []byte) ([]byte, error) {
+	sum := sha256.Sum256(data)
+	signature, err := s.key.Sign(sum[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeSig(signature), nil
+}
+
+// signerPublicKey returns the compressed secp256k1 public key of s.
+func signerPublicKey(s Signer) (secp256k1.PubKeySecp256k1, error) {
+	pk, ok := s.PublicKey().(secp256k1.PubKeySecp256k1)
+	if !ok {
+		return secp256k1.PubKeySecp256k1{}, fmt.Errorf("unsupported public key type %T", s.PublicKey())
+	}
+
+	return pk, nil
+}
+
+// signWith signs data with s, and checks that the returned signature is well-formed.
+func signWith(s Signer, data []byte) ([]byte, error) {
+	signature, err := s.Sign(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(signature) != 64 {
+		return nil, errors.New("signature must be 64 bytes long")
+	}
+
+	return signature, nil
+}
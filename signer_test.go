@@ -0,0 +1,141 @@
+package commercio
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// fakeSigner is a Signer returning a fixed signature.
+type fakeSigner struct {
+	publicKey crypto.PubKey
+	signature []byte
+	err       error
+}
+
+func (f fakeSigner) Address() string          { return "did:com:fake" }
+func (f fakeSigner) PublicKey() crypto.PubKey { return f.publicKey }
+func (f fakeSigner) Sign([]byte) ([]byte, error) {
+	return f.signature, f.err
+}
+
+func TestNewMnemonicSigner(t *testing.T) {
+	mnemonic := "first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus"
+
+	s, err := NewMnemonicSigner(mnemonic, sacco.CosmosDerivationPath)
+	require.NoError(t, err)
+
+	w, err := sacco.FromMnemonic(hrp, mnemonic, sacco.CosmosDerivationPath)
+	require.NoError(t, err)
+
+	require.Equal(t, w.Address, s.Address())
+
+	sdk, err := NewSDKWithSigner(s, DefaultSDKConfig)
+	require.NoError(t, err)
+	require.Equal(t, w.Address, sdk.Address)
+	require.Equal(t, w.PublicKeyBech32, sdk.PublicKey)
+
+	data := []byte("data")
+	signature, err := s.Sign(data)
+	require.NoError(t, err)
+	require.True(t, s.PublicKey().VerifyBytes(data, signature))
+
+	_, err = NewMnemonicSigner("", sacco.CosmosDerivationPath)
+	require.Error(t, err)
+}
+
+func TestNewSDKWithSigner(t *testing.T) {
+	tests := []struct {
+		name    string
+		signer  Signer
+		config  SDKConfig
+		wantErr bool
+	}{
+		{
+			"missing signer",
+			nil,
+			DefaultSDKConfig,
+			true,
+		},
+		{
+			"invalid config",
+			fakeSigner{publicKey: secp256k1.GenPrivKey().PubKey()},
+			SDKConfig{},
+			true,
+		},
+		{
+			"unsupported public key",
+			fakeSigner{publicKey: ed25519.GenPrivKey().PubKey()},
+			DefaultSDKConfig,
+			true,
+		},
+		{
+			"secp256k1 signer",
+			fakeSigner{publicKey: secp256k1.GenPrivKey().PubKey()},
+			DefaultSDKConfig,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := NewSDKWithSigner(tt.signer, tt.config)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "did:com:fake", res.Address)
+		})
+	}
+}
+
+func TestSDK_SignTx_signer(t *testing.T) {
+	tests := []struct {
+		name    string
+		signer  fakeSigner
+		wantErr bool
+	}{
+		{
+			"signer error",
+			fakeSigner{err: errors.New("error!")},
+			true,
+		},
+		{
+			"malformed signature",
+			fakeSigner{signature: []byte("short")},
+			true,
+		},
+		{
+			"well-formed signature",
+			fakeSigner{signature: make([]byte, 64)},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.signer.publicKey = secp256k1.GenPrivKey().PubKey()
+
+			sdk, err := NewSDKWithSigner(tt.signer, DefaultSDKConfig)
+			require.NoError(t, err)
+
+			res, err := sdk.SignTx(UnsignedTx{}, 42, 7, "commercio-testnet")
+
+			if tt.wantErr {
+				require.True(t, errors.Is(err, ErrSigning))
+				require.Equal(t, SignedTx{}, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, res.Signatures, 1)
+		})
+	}
+}
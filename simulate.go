@@ -0,0 +1,116 @@
+package commercio
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// rpcQueryResponse is the Tendermint RPC response to an abci_query request.
+type rpcQueryResponse struct {
+	Result struct {
+		Response struct {
+			Code  uint32 `json:"code"`
+			Log   string `json:"log"`
+			Value []byte `json:"value"`
+		} `json:"response"`
+	} `json:"result"`
+	Error *struct {
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+}
+
+// SimulateTransaction builds a transaction containing rawMsgs, exactly as SendTransaction does, asks the
+// pre-defined RPC endpoint to simulate it and returns the amount of gas it used.
+func (sdk *SDK) SimulateTransaction(rawMsgs ...interface{}) (uint64, error) {
+	txp, err := sdk.genTx(rawMsgs...)
+	if err != nil {
+		return 0, err
+	}
+
+	return sdk.simulate(txp)
+}
+
+// simulate asks the pre-defined RPC endpoint to simulate txp, and returns the amount of gas it used.
+// txp doesn't need to be signed, since signatures are not checked during a simulation.
+func (sdk *SDK) simulate(txp sacco.TransactionPayload) (uint64, error) {
+	e := func(ext error) (uint64, error) {
+		return 0, fmt.Errorf("%w, %s", ErrSimulation, ext.Error())
+	}
+
+	if sdk.config.RPCEndpoint == "" {
+		return e(fmt.Errorf("missing RPC endpoint"))
+	}
+
+	txp.Signatures = nil
+	jsonTx, err := json.Marshal(txp)
+	if err != nil {
+		return e(err)
+	}
+
+	var stdTx auth.StdTx
+	jsonStdTx, err := json.Marshal(messageEnclosure{
+		Type:  sdk.typeMapping.cosmosType(stdTx),
+		Value: jsonTx,
+	})
+	if err != nil {
+		return e(err)
+	}
+
+	if err := sdk.codec.UnmarshalJSON(jsonStdTx, &stdTx); err != nil {
+		return e(err)
+	}
+
+	// the node expects an empty signature for the signer, to estimate the gas needed to verify it
+	stdTx.Signatures = []auth.StdSignature{{}}
+
+	txBytes, err := sdk.codec.MarshalBinaryLengthPrefixed(stdTx)
+	if err != nil {
+		return e(err)
+	}
+
+	params := url.Values{}
+	params.Set("path", strconv.Quote("/app/simulate"))
+	params.Set("data", "0x"+hex.EncodeToString(txBytes))
+
+	resp, err := http.Get(fmt.Sprintf("%s/abci_query?%s", sdk.config.RPCEndpoint, params.Encode()))
+	if err != nil {
+		return e(err)
+	}
+
+	defer resp.Body.Close()
+
+	var qr rpcQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&qr); err != nil {
+		return e(fmt.Errorf("could not decode RPC response with status %d: %w", resp.StatusCode, err))
+	}
+
+	if qr.Error != nil {
+		return e(fmt.Errorf("%s %s", qr.Error.Message, qr.Error.Data))
+	}
+
+	if qr.Result.Response.Code != 0 {
+		return e(fmt.Errorf("code %d: %s", qr.Result.Response.Code, qr.Result.Response.Log))
+	}
+
+	var gasUsed uint64
+	if err := codec.Cdc.UnmarshalBinaryLengthPrefixed(qr.Result.Response.Value, &gasUsed); err != nil {
+		return e(err)
+	}
+
+	return gasUsed, nil
+}
+
+// adjustedGas returns gasUsed multiplied by adjustment, rounded up.
+func adjustedGas(gasUsed uint64, adjustment float64) uint64 {
+	return uint64(math.Ceil(float64(gasUsed) * adjustment))
+}
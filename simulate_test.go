@@ -0,0 +1,195 @@
+package commercio
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/commercionetwork/sacco.go"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func simulationResponder(gasUsed uint64) httpmock.Responder {
+	return httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      -1,
+		"result": map[string]interface{}{
+			"response": map[string]interface{}{
+				"code":  0,
+				"value": codec.Cdc.MustMarshalBinaryLengthPrefixed(gasUsed),
+			},
+		},
+	})
+}
+
+func TestSDK_SimulateTransaction(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	addr, err := Address(testAddress)
+	require.NoError(t, err)
+
+	msg := MsgSend{FromAddress: addr, ToAddress: addr}
+
+	tests := []struct {
+		name        string
+		msgs        []interface{}
+		rpcEndpoint string
+		responder   httpmock.Responder
+		want        uint64
+		wantErr     bool
+	}{
+		{
+			"trigger error in genTx",
+			nil,
+			"http://localhost:26657",
+			simulationResponder(42),
+			0,
+			true,
+		},
+		{
+			"missing rpc endpoint",
+			[]interface{}{msg},
+			"",
+			simulationResponder(42),
+			0,
+			true,
+		},
+		{
+			"message is not a cosmos message",
+			[]interface{}{"hello"},
+			"http://localhost:26657",
+			simulationResponder(42),
+			0,
+			true,
+		},
+		{
+			"rpc returns a malformed response",
+			[]interface{}{msg},
+			"http://localhost:26657",
+			httpmock.NewStringResponder(http.StatusInternalServerError, "aaa"),
+			0,
+			true,
+		},
+		{
+			"rpc returns an error",
+			[]interface{}{msg},
+			"http://localhost:26657",
+			httpmock.NewJsonResponderOrPanic(http.StatusInternalServerError, map[string]interface{}{
+				"error": map[string]interface{}{"message": "Internal error", "data": "error!"},
+			}),
+			0,
+			true,
+		},
+		{
+			"simulation fails",
+			[]interface{}{msg},
+			"http://localhost:26657",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]interface{}{
+				"result": map[string]interface{}{
+					"response": map[string]interface{}{"code": 2, "log": "failed to decode tx"},
+				},
+			}),
+			0,
+			true,
+		},
+		{
+			"simulation returns gas used",
+			[]interface{}{msg, msg},
+			"http://localhost:26657",
+			simulationResponder(42),
+			42,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost:26657/abci_query", tt.responder)
+
+			sdk.config.RPCEndpoint = tt.rpcEndpoint
+			defer func() {
+				sdk.config.RPCEndpoint = DefaultSDKConfig.RPCEndpoint
+			}()
+
+			res, err := sdk.SimulateTransaction(tt.msgs...)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Zero(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestSDK_SendTransaction_simulate(t *testing.T) {
+	config := DefaultSDKConfig
+	config.FeePolicy.Simulate = true
+	config.FeePolicy.GasAdjustment = 1.5
+
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", config)
+	require.NoError(t, err)
+
+	addr, err := Address(testAddress)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		responder httpmock.Responder
+		wantGas   string
+		wantErr   bool
+	}{
+		{
+			"simulation fails",
+			httpmock.NewStringResponder(http.StatusInternalServerError, "aaa"),
+			"",
+			true,
+		},
+		{
+			"simulated gas gets adjusted",
+			simulationResponder(1001),
+			"1502",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			var sentBody sacco.TxBody
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost:26657/abci_query", tt.responder)
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/node_info", httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.NodeInfo{}))
+			httpmock.RegisterResponder(http.MethodPost, "http://localhost:1317/txs", func(req *http.Request) (*http.Response, error) {
+				if err := json.NewDecoder(req.Body).Decode(&sentBody); err != nil {
+					return nil, err
+				}
+
+				return httpmock.NewJsonResponse(http.StatusOK, sacco.TxResponse{TxHash: "ok!"})
+			})
+			httpmock.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile("http://localhost:1317/auth/accounts/(.+)"), httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.AccountData{Result: sacco.AccountDataResult{Value: sacco.AccountDataValue{Address: "address"}}}))
+
+			res, err := sdk.SendTransaction(MsgSend{FromAddress: addr, ToAddress: addr})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrSimulation))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "ok!", res)
+			require.Equal(t, tt.wantGas, sentBody.Tx.Fee.Gas)
+		})
+	}
+}
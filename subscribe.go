@@ -0,0 +1,188 @@
+package commercio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/commercionetwork/commercionetwork/x/docs"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	// subscriber is the name the SDK subscribes to Tendermint events with.
+	subscriber = "commercio-sdk"
+
+	// subscriptionCapacity is the number of events buffered for each subscription.
+	subscriptionCapacity = 100
+)
+
+// IncomingMessage is a message addressed to the account associated to an SDK, included in a block.
+type IncomingMessage struct {
+	// Height is the height of the block containing the transaction.
+	Height int64
+
+	// TxHash is the hash of the transaction containing Message.
+	TxHash string
+
+	// Message is either a MsgSend, a MsgShareDocument or a MsgSendDocumentReceipt.
+	Message interface{}
+
+	// Err is an error happened while decoding a transaction, the subscription goes on after it.
+	Err error
+}
+
+// incomingFilter returns the messages of a transaction addressed to an account.
+type incomingFilter func(msg types.Msg, address types.AccAddress) (interface{}, bool)
+
+// SubscribeIncoming connects to the websocket of the pre-defined Tendermint RPC endpoint, and delivers the
+// MsgSend, MsgShareDocument and MsgSendDocumentReceipt messages addressed to the account associated to sdk as soon
+// as they get included in a block.
+// Since x/docs doesn't emit recipient-specific events, all the documents and receipts are received from the
+// endpoint, and filtered by the SDK.
+// The subscription stops and the channel gets closed when ctx is done.
+// ErrSubscription is returned if the SDK has been configured without an RPCEndpoint.
+func (sdk *SDK) SubscribeIncoming(ctx context.Context) (<-chan IncomingMessage, error) {
+	e := func(ext error) (<-chan IncomingMessage, error) {
+		return nil, fmt.Errorf("%w, %s", ErrSubscription, ext.Error())
+	}
+
+	if sdk.config.RPCEndpoint == "" {
+		return e(errors.New("missing RPC endpoint, SDKConfig.RPCEndpoint is needed to subscribe"))
+	}
+
+	address, err := types.AccAddressFromBech32(sdk.Address)
+	if err != nil {
+		return e(err)
+	}
+
+	client, err := rpcclient.NewHTTP(sdk.config.RPCEndpoint, "/websocket")
+	if err != nil {
+		return e(err)
+	}
+
+	if err := client.Start(); err != nil {
+		return e(err)
+	}
+
+	filters := map[string]incomingFilter{
+		fmt.Sprintf("tm.event='Tx' AND transfer.recipient='%s'", address): sentTo,
+		"tm.event='Tx' AND message.action='shareDocument'":                sharedWith,
+		"tm.event='Tx' AND message.action='sendDocumentReceipt'":          receiptFor,
+	}
+
+	incoming := make(chan IncomingMessage)
+	done := make(chan struct{}, len(filters))
+
+	for query, filter := range filters {
+		events, err := client.Subscribe(ctx, subscriber, query, subscriptionCapacity)
+		if err != nil {
+			_ = client.Stop()
+			return e(err)
+		}
+
+		go func(events <-chan ctypes.ResultEvent, filter incomingFilter) {
+			defer func() { done <- struct{}{} }()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event, ok := <-events:
+					if !ok {
+						return
+					}
+
+					for _, msg := range sdk.incomingMessages(event, address, filter) {
+						select {
+						case <-ctx.Done():
+							return
+						case incoming <- msg:
+						}
+					}
+				}
+			}
+		}(events, filter)
+	}
+
+	go func() {
+		for range filters {
+			<-done
+		}
+
+		_ = client.UnsubscribeAll(context.Background(), subscriber)
+		_ = client.Stop()
+
+		close(incoming)
+	}()
+
+	return incoming, nil
+}
+
+// incomingMessages decodes the transaction contained in event, and returns its messages addressed to address
+// according to filter.
+func (sdk *SDK) incomingMessages(event ctypes.ResultEvent, address types.AccAddress, filter incomingFilter) []IncomingMessage {
+	data, ok := event.Data.(tmtypes.EventDataTx)
+	if !ok || data.Result.IsErr() {
+		return nil
+	}
+
+	txHash := fmt.Sprintf("%X", tmtypes.Tx(data.Tx).Hash())
+
+	var tx auth.StdTx
+	if err := sdk.codec.UnmarshalBinaryLengthPrefixed(data.Tx, &tx); err != nil {
+		return []IncomingMessage{{
+			Height: data.Height,
+			TxHash: txHash,
+			Err:    fmt.Errorf("%w, cannot decode transaction, %s", ErrSubscription, err.Error()),
+		}}
+	}
+
+	var messages []IncomingMessage
+	for _, msg := range tx.Msgs {
+		if m, ok := filter(msg, address); ok {
+			messages = append(messages, IncomingMessage{
+				Height:  data.Height,
+				TxHash:  txHash,
+				Message: m,
+			})
+		}
+	}
+
+	return messages
+}
+
+// sentTo returns msg as a MsgSend if it sends coins to address.
+func sentTo(msg types.Msg, address types.AccAddress) (interface{}, bool) {
+	m, ok := msg.(bank.MsgSend)
+	if !ok || !m.ToAddress.Equals(address) {
+		return nil, false
+	}
+
+	return MsgSend(m), true
+}
+
+// sharedWith returns msg as a MsgShareDocument if it shares a document with address.
+func sharedWith(msg types.Msg, address types.AccAddress) (interface{}, bool) {
+	m, ok := msg.(docs.MsgShareDocument)
+	if !ok || !m.Recipients.Contains(address) {
+		return nil, false
+	}
+
+	return MsgShareDocument(m), true
+}
+
+// receiptFor returns msg as a MsgSendDocumentReceipt if it sends a document receipt to address.
+func receiptFor(msg types.Msg, address types.AccAddress) (interface{}, bool) {
+	m, ok := msg.(docs.MsgSendDocumentReceipt)
+	if !ok || !m.Recipient.Equals(address) {
+		return nil, false
+	}
+
+	return MsgSendDocumentReceipt(m), true
+}
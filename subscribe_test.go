@@ -0,0 +1,145 @@
+package commercio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/commercionetwork/commercionetwork/x/docs"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	amino "github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// fakeTendermint returns a websocket server which acknowledges each subscription, then sends the events associated
+// to its query.
+func fakeTendermint(events map[string][]tmtypes.EventDataTx) *httptest.Server {
+	cdc := amino.NewCodec()
+	ctypes.RegisterAmino(cdc)
+
+	upgrader := websocket.Upgrader{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			var req rpctypes.RPCRequest
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+
+			var params struct {
+				Query string `json:"query"`
+			}
+			if err := cdc.UnmarshalJSON(req.Params, &params); err != nil {
+				return
+			}
+
+			_ = conn.WriteJSON(rpctypes.NewRPCSuccessResponse(cdc, req.ID, struct{}{}))
+
+			for _, data := range events[params.Query] {
+				event := ctypes.ResultEvent{Query: params.Query, Data: data}
+				if err := conn.WriteJSON(rpctypes.NewRPCSuccessResponse(cdc, req.ID, event)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+}
+
+func TestSDK_SubscribeIncoming(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	self, err := Address(sdk.Address)
+	require.NoError(t, err)
+
+	other := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	encode := func(msgs ...types.Msg) []byte {
+		tx, err := sdk.codec.MarshalBinaryLengthPrefixed(auth.StdTx{Msgs: msgs})
+		require.NoError(t, err)
+		return tx
+	}
+
+	send := bank.MsgSend{FromAddress: other, ToAddress: self, Amount: types.NewCoins(types.NewInt64Coin("ucommercio", 1))}
+	share := docs.MsgShareDocument{Sender: other, Recipients: []types.AccAddress{self}, UUID: "uuid"}
+
+	server := fakeTendermint(map[string][]tmtypes.EventDataTx{
+		"tm.event='Tx' AND transfer.recipient='" + sdk.Address + "'": {
+			// failed transactions are skipped
+			{TxResult: tmtypes.TxResult{Height: 1, Tx: encode(send), Result: abci.ResponseDeliverTx{Code: 5}}},
+			{TxResult: tmtypes.TxResult{Height: 2, Tx: encode(send)}},
+		},
+		"tm.event='Tx' AND message.action='shareDocument'": {
+			// documents shared with others are skipped
+			{TxResult: tmtypes.TxResult{Height: 3, Tx: encode(docs.MsgShareDocument{Sender: self, Recipients: []types.AccAddress{other}, UUID: "other"})}},
+			{TxResult: tmtypes.TxResult{Height: 4, Tx: encode(share)}},
+		},
+		"tm.event='Tx' AND message.action='sendDocumentReceipt'": {
+			{TxResult: tmtypes.TxResult{Height: 5, Tx: []byte("bogus")}},
+		},
+	})
+	defer server.Close()
+
+	sdk.config.RPCEndpoint = server.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	incoming, err := sdk.SubscribeIncoming(ctx)
+	require.NoError(t, err)
+
+	received := map[int64]IncomingMessage{}
+	timeout := time.After(5 * time.Second)
+	for len(received) < 3 {
+		select {
+		case msg := <-incoming:
+			received[msg.Height] = msg
+		case <-timeout:
+			require.FailNow(t, "timed out waiting for messages", "%+v", received)
+		}
+	}
+
+	require.Equal(t, MsgSend(send), received[2].Message)
+	require.Equal(t, fmt.Sprintf("%X", tmtypes.Tx(encode(send)).Hash()), received[2].TxHash)
+	require.Equal(t, MsgShareDocument(share), received[4].Message)
+	require.Error(t, received[5].Err)
+
+	cancel()
+
+	for msg := range incoming {
+		require.Fail(t, "unexpected message", "%+v", msg)
+	}
+}
+
+func TestSDK_SubscribeIncoming_errors(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	sdk.config.RPCEndpoint = ""
+	_, err = sdk.SubscribeIncoming(context.Background())
+	require.True(t, errors.Is(err, ErrSubscription))
+	require.Contains(t, err.Error(), "missing RPC endpoint")
+
+	// nothing listening here
+	sdk.config.RPCEndpoint = "http://127.0.0.1:1"
+	_, err = sdk.SubscribeIncoming(context.Background())
+	require.Error(t, err)
+}
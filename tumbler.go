@@ -0,0 +1,94 @@
+package commercio
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// defaultTumblerKeyTTL is how long the tumbler key is cached when SDKConfig.TumblerKeyTTL is zero.
+const defaultTumblerKeyTTL = time.Hour
+
+// tumblerResponse is the LCD response to a /government/tumbler query.
+type tumblerResponse struct {
+	TumblerAddress string `json:"tumbler_address"`
+}
+
+// tumblerKeyCache holds the last tumbler key fetched by an SDK, shared between all the goroutines using it.
+type tumblerKeyCache struct {
+	mu      sync.Mutex
+	key     string
+	expires time.Time
+}
+
+// TumblerAddress returns the address of the tumbler, the account handling power-up requests.
+func (sdk *SDK) TumblerAddress() (types.AccAddress, error) {
+	var tr tumblerResponse
+	if err := sdk.query("/government/tumbler", &tr); err != nil {
+		return nil, err
+	}
+
+	address, err := types.AccAddressFromBech32(tr.TumblerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w, invalid tumbler address, %s", ErrQuery, err.Error())
+	}
+
+	return address, nil
+}
+
+// TumblerKey returns the PEM-encoded RSA public key power-up request proofs must be encrypted with, which is the
+// verification key listed in the tumbler DidDocument.
+// The key is cached for SDKConfig.TumblerKeyTTL.
+func (sdk *SDK) TumblerKey() (string, error) {
+	sdk.tumbler.mu.Lock()
+	defer sdk.tumbler.mu.Unlock()
+
+	if sdk.tumbler.key != "" && time.Now().Before(sdk.tumbler.expires) {
+		return sdk.tumbler.key, nil
+	}
+
+	address, err := sdk.TumblerAddress()
+	if err != nil {
+		return "", err
+	}
+
+	didDocument, err := sdk.Identity(address)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := verificationKey(didDocument)
+	if err != nil {
+		return "", fmt.Errorf("%w, tumbler %s, %s", ErrInvalidTumblerKey, address, err.Error())
+	}
+
+	ttl := sdk.config.TumblerKeyTTL
+	if ttl == 0 {
+		ttl = defaultTumblerKeyTTL
+	}
+
+	sdk.tumbler.key = key
+	sdk.tumbler.expires = time.Now().Add(ttl)
+
+	return key, nil
+}
+
+// verificationKey returns the RSA verification key listed as #keys-1 in didDocument.
+func verificationKey(didDocument DidDocument) (string, error) {
+	for _, key := range didDocument.PubKeys {
+		if !strings.HasSuffix(key.ID, "#keys-1") || key.Type != KeyTypeRsaVerification {
+			continue
+		}
+
+		if _, _, err := readKey(strings.NewReader(key.PublicKeyPem), typePublicKey); err != nil {
+			return "", err
+		}
+
+		return key.PublicKeyPem, nil
+	}
+
+	return "", fmt.Errorf("no %s key #keys-1 found", KeyTypeRsaVerification)
+}
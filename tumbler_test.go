@@ -0,0 +1,198 @@
+package commercio
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	id "github.com/commercionetwork/commercionetwork/x/id/types"
+	"github.com/commercionetwork/sacco.go"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// registerTumblerResponders registers the LCD responders describing a tumbler whose DidDocument lists keys, and
+// returns its address.
+func registerTumblerResponders(t *testing.T, sdk *SDK, keys func(tumbler *SDK) []PubKey) string {
+	am, err := NewAccountManager("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	tumbler, err := am.Account(0, 1)
+	require.NoError(t, err)
+
+	tumblerAddress, err := types.AccAddressFromBech32(tumbler.Address)
+	require.NoError(t, err)
+
+	// the DidDocument is not validated when decoded, build it by hand so that it can contain invalid keys
+	didDocument := DidDocument{Context: "https://www.w3.org/ns/did/v1", ID: tumblerAddress}
+	for _, key := range keys(tumbler) {
+		didDocument.PubKeys = append(didDocument.PubKeys, id.PubKey(key))
+	}
+
+	identity, err := sdk.codec.MarshalJSON(identityResponse{Owner: tumblerAddress, DidDocument: &didDocument})
+	require.NoError(t, err)
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/government/tumbler", lcdResponder(`{"tumbler_address":"`+tumbler.Address+`"}`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/identities/"+tumbler.Address, lcdResponder(string(identity)))
+
+	return tumbler.Address
+}
+
+func TestSDK_TumblerKey(t *testing.T) {
+	_, verificationPublicKey := testRSAKeypair(t)
+	_, signaturePublicKey := testRSAKeypair(t)
+
+	validKeys := func(tumbler *SDK) []PubKey {
+		vk, err := tumbler.NewDidPubKey(1, KeyTypeRsaVerification, strings.NewReader(verificationPublicKey))
+		require.NoError(t, err)
+		sk, err := tumbler.NewDidPubKey(2, KeyTypeRsaSignature, strings.NewReader(signaturePublicKey))
+		require.NoError(t, err)
+
+		// key order must not matter
+		return []PubKey{sk, vk}
+	}
+
+	tests := []struct {
+		name    string
+		keys    func(tumbler *SDK) []PubKey
+		wantErr error
+	}{
+		{
+			"verification key found",
+			validKeys,
+			nil,
+		},
+		{
+			"missing verification key",
+			func(tumbler *SDK) []PubKey {
+				return validKeys(tumbler)[:1]
+			},
+			ErrInvalidTumblerKey,
+		},
+		{
+			"invalid verification key",
+			func(tumbler *SDK) []PubKey {
+				keys := validKeys(tumbler)
+				keys[1].PublicKeyPem = "not a key"
+				return keys
+			},
+			ErrInvalidTumblerKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+			require.NoError(t, err)
+
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			tumbler := registerTumblerResponders(t, sdk, tt.keys)
+
+			key, err := sdk.TumblerKey()
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.wantErr))
+				require.Empty(t, key)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, verificationPublicKey, key)
+
+			// the key is cached
+			key, err = sdk.TumblerKey()
+			require.NoError(t, err)
+			require.Equal(t, verificationPublicKey, key)
+
+			calls := httpmock.GetCallCountInfo()
+			require.Equal(t, 1, calls["GET http://localhost:1317/government/tumbler"])
+			require.Equal(t, 1, calls["GET http://localhost:1317/identities/"+tumbler])
+		})
+	}
+}
+
+func TestSDK_TumblerKey_expiration(t *testing.T) {
+	config := DefaultSDKConfig
+	config.TumblerKeyTTL = time.Nanosecond
+
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", config)
+	require.NoError(t, err)
+
+	_, rsaKey := testRSAKeypair(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerTumblerResponders(t, sdk, func(tumbler *SDK) []PubKey {
+		vk, err := tumbler.NewDidPubKey(1, KeyTypeRsaVerification, strings.NewReader(rsaKey))
+		require.NoError(t, err)
+		return []PubKey{vk}
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := sdk.TumblerKey()
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 2, httpmock.GetCallCountInfo()["GET http://localhost:1317/government/tumbler"])
+}
+
+func TestSDK_TumblerAddress(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/government/tumbler", lcdResponder(`{"tumbler_address":"tumbler"}`))
+	_, err = sdk.TumblerAddress()
+	require.True(t, errors.Is(err, ErrQuery))
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/government/tumbler", httpmock.NewJsonResponderOrPanic(http.StatusNotFound, sacco.Error{Error: "not found"}))
+	_, err = sdk.TumblerKey()
+	require.True(t, errors.Is(err, ErrQuery))
+}
+
+func TestSDK_BuildPowerupRequest_tumblerKeyDiscovery(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	tumblerPrivateKey, tumblerPublicKey := testRSAKeypair(t)
+	signatureKey, _ := testRSAKeypair(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerTumblerResponders(t, sdk, func(tumbler *SDK) []PubKey {
+		vk, err := tumbler.NewDidPubKey(1, KeyTypeRsaVerification, strings.NewReader(tumblerPublicKey))
+		require.NoError(t, err)
+		return []PubKey{vk}
+	})
+
+	msg, err := sdk.BuildPowerupRequest(PowerUpParams{
+		PubKey:          sdk.PublicKey,
+		SignatureKey:    strings.NewReader(signatureKey),
+		Amount:          42,
+		PairwiseAddress: types.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+	})
+	require.NoError(t, err)
+
+	// the proof key has been encrypted with the discovered tumbler key
+	_, rawKey, err := readKey(strings.NewReader(tumblerPrivateKey), typePrivateKey)
+	require.NoError(t, err)
+
+	encryptedKey, err := base64.StdEncoding.DecodeString(msg.ProofKey)
+	require.NoError(t, err)
+
+	_, err = rsa.DecryptPKCS1v15(rand.Reader, rawKey.(*rsa.PrivateKey), encryptedKey)
+	require.NoError(t, err)
+}
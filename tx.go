@@ -0,0 +1,169 @@
+package commercio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	sdkErr "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+var (
+	// txPollInterval is the time WaitForTx waits before polling the LCD for the second time, it has been defined
+	// for ease of testing.
+	txPollInterval = 500 * time.Millisecond
+
+	// txPollMaxInterval is the maximum time WaitForTx waits between two LCD polls, it has been defined for ease of
+	// testing.
+	txPollMaxInterval = 5 * time.Second
+)
+
+// TxResult represents the outcome of a transaction included in a block.
+type TxResult struct {
+	// Hash is the transaction hash.
+	Hash string
+
+	// Height is the height of the block which includes the transaction.
+	Height int64
+
+	// GasWanted is the gas limit of the transaction.
+	GasWanted int64
+
+	// GasUsed is the amount of gas used to execute the transaction.
+	GasUsed int64
+
+	// Code is the ABCI code returned by the transaction execution, zero if the transaction succeeded.
+	Code uint32
+
+	// Codespace is the namespace of Code.
+	Codespace string
+
+	// RawLog is the raw log output of the transaction execution.
+	RawLog string
+
+	// Logs are the parsed logs of each message contained in the transaction.
+	Logs types.ABCIMessageLogs
+
+	// Events are the events emitted by the messages contained in the transaction.
+	Events types.StringEvents
+}
+
+// newTxResult returns a TxResult built upon the values of r.
+func newTxResult(r types.TxResponse) TxResult {
+	var events types.StringEvents
+	for _, l := range r.Logs {
+		events = append(events, l.Events...)
+	}
+
+	return TxResult{
+		Hash:      r.TxHash,
+		Height:    r.Height,
+		GasWanted: r.GasWanted,
+		GasUsed:   r.GasUsed,
+		Code:      r.Code,
+		Codespace: r.Codespace,
+		RawLog:    r.RawLog,
+		Logs:      r.Logs,
+		Events:    events,
+	}
+}
+
+// Failed returns true if the transaction was included in a block, but its execution failed.
+func (tr TxResult) Failed() bool {
+	return tr.Code != 0
+}
+
+// ABCIError returns the error associated to the transaction ABCI code, or nil if the transaction succeeded.
+// Errors registered by Cosmos modules can be checked with errors.Is.
+func (tr TxResult) ABCIError() error {
+	if !tr.Failed() {
+		return nil
+	}
+
+	return sdkErr.ABCIError(tr.Codespace, tr.Code, tr.RawLog)
+}
+
+// WaitForTx polls the pre-defined LCD until the transaction identified by hash is included in a block, then returns
+// its result.
+// An ErrTxTimeout error is returned if the transaction isn't included in a block within timeout, while an
+// ErrTxFailed error is returned along with the result if the transaction execution failed.
+func (sdk *SDK) WaitForTx(hash string, timeout time.Duration) (TxResult, error) {
+	deadline := time.Now().Add(timeout)
+	interval := txPollInterval
+
+	for {
+		res, found, err := sdk.queryTx(hash)
+		if err != nil {
+			return TxResult{}, err
+		}
+
+		if found {
+			if res.Failed() {
+				return res, fmt.Errorf("%w, %s", ErrTxFailed, res.ABCIError().Error())
+			}
+
+			return res, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return TxResult{}, fmt.Errorf("%w, transaction %s not found after %s", ErrTxTimeout, hash, timeout)
+		}
+
+		if interval > remaining {
+			interval = remaining
+		}
+
+		time.Sleep(interval)
+
+		interval *= 2
+		if interval > txPollMaxInterval {
+			interval = txPollMaxInterval
+		}
+	}
+}
+
+// SendTransactionAndWait sends all the messages contained in rawMsgs like SendTransaction does, then waits for the
+// transaction to be included in a block like WaitForTx does.
+func (sdk *SDK) SendTransactionAndWait(timeout time.Duration, rawMsgs ...interface{}) (TxResult, error) {
+	hash, err := sdk.SendTransaction(rawMsgs...)
+	if err != nil {
+		return TxResult{}, err
+	}
+
+	return sdk.WaitForTx(hash, timeout)
+}
+
+// queryTx queries the pre-defined LCD for the transaction identified by hash.
+// The boolean returned is false if the LCD doesn't know about the transaction yet.
+func (sdk *SDK) queryTx(hash string) (TxResult, bool, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/txs/%s", sdk.config.LCDEndpoint, hash))
+	if err != nil {
+		return TxResult{}, false, fmt.Errorf("%w, %s", ErrQuery, err.Error())
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return TxResult{}, false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return TxResult{}, false, lcdError(json.NewDecoder(resp.Body), resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return TxResult{}, false, fmt.Errorf("%w, %s", ErrQuery, err.Error())
+	}
+
+	var txr types.TxResponse
+	if err := sdk.codec.UnmarshalJSON(body, &txr); err != nil {
+		return TxResult{}, false, fmt.Errorf("%w, %s", ErrQuery, err.Error())
+	}
+
+	return newTxResult(txr), true, nil
+}
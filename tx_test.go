@@ -0,0 +1,162 @@
+package commercio
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/commercionetwork/sacco.go"
+	sdkErr "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	okTxResponse     = `{"height":"10","txhash":"hash","gas_wanted":"200000","gas_used":"42","logs":[{"msg_index":0,"log":"","events":[{"type":"message","attributes":[{"key":"action","value":"send"}]}]}]}`
+	failedTxResponse = `{"height":"10","txhash":"hash","codespace":"sdk","code":5,"raw_log":"insufficient funds","gas_wanted":"200000","gas_used":"42"}`
+)
+
+// notFoundThen returns a responder which replies with a 404 for the first times calls, then uses responder.
+func notFoundThen(times int, responder httpmock.Responder) httpmock.Responder {
+	calls := 0
+	return func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls <= times {
+			return httpmock.NewJsonResponse(http.StatusNotFound, sacco.Error{Error: "not found"})
+		}
+
+		return responder(req)
+	}
+}
+
+func TestSDK_WaitForTx(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	txPollInterval = time.Millisecond
+	txPollMaxInterval = 2 * time.Millisecond
+	defer func() {
+		txPollInterval = 500 * time.Millisecond
+		txPollMaxInterval = 5 * time.Second
+	}()
+
+	tests := []struct {
+		name       string
+		responder  httpmock.Responder
+		wantErr    error
+		wantHeight int64
+	}{
+		{
+			"transaction never included",
+			notFoundThen(1000000, nil),
+			ErrTxTimeout,
+			0,
+		},
+		{
+			"LCD error",
+			httpmock.NewJsonResponderOrPanic(http.StatusInternalServerError, sacco.Error{Error: "error!"}),
+			ErrQuery,
+			0,
+		},
+		{
+			"malformed transaction response",
+			httpmock.NewStringResponder(http.StatusOK, "aaa"),
+			ErrQuery,
+			0,
+		},
+		{
+			"transaction failed",
+			notFoundThen(2, httpmock.NewStringResponder(http.StatusOK, failedTxResponse)),
+			ErrTxFailed,
+			10,
+		},
+		{
+			"transaction included after some polls",
+			notFoundThen(3, httpmock.NewStringResponder(http.StatusOK, okTxResponse)),
+			nil,
+			10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/txs/hash", tt.responder)
+
+			res, err := sdk.WaitForTx("hash", 50*time.Millisecond)
+
+			require.Equal(t, tt.wantHeight, res.Height)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "hash", res.Hash)
+			require.Equal(t, int64(42), res.GasUsed)
+			require.Len(t, res.Events, 1)
+			require.Equal(t, "message", res.Events[0].Type)
+		})
+	}
+}
+
+func TestTxResult_ABCIError(t *testing.T) {
+	res := TxResult{}
+	require.False(t, res.Failed())
+	require.NoError(t, res.ABCIError())
+
+	res = TxResult{Codespace: sdkErr.RootCodespace, Code: 5, RawLog: "insufficient funds"}
+	require.True(t, res.Failed())
+	require.True(t, errors.Is(res.ABCIError(), sdkErr.ErrInsufficientFunds))
+}
+
+func TestSDK_SendTransactionAndWait(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		msgs      []interface{}
+		responder httpmock.Responder
+		wantErr   bool
+	}{
+		{
+			"error while sending",
+			nil,
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "hash"}),
+			true,
+		},
+		{
+			"transaction sent and included",
+			[]interface{}{MsgSend{}},
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.TxResponse{TxHash: "hash"}),
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/node_info", httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.NodeInfo{}))
+			httpmock.RegisterResponder(http.MethodPost, "http://localhost:1317/txs", tt.responder)
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/txs/hash", httpmock.NewStringResponder(http.StatusOK, okTxResponse))
+			httpmock.RegisterRegexpResponder(http.MethodGet, regexp.MustCompile("http://localhost:1317/auth/accounts/(.+)"), httpmock.NewJsonResponderOrPanic(http.StatusOK, sacco.AccountData{Result: sacco.AccountDataResult{Value: sacco.AccountDataValue{Address: "address"}}}))
+
+			res, err := sdk.SendTransactionAndWait(time.Second, tt.msgs...)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, int64(10), res.Height)
+		})
+	}
+}
@@ -43,8 +43,10 @@ type (
 	PubKey               id.PubKey
 	Proof                id.Proof
 	Services             id.Services
+	Service              id.Service
 	MsgSetIdentity       id.MsgSetIdentity
 	MsgRequestDidPowerUp id.MsgRequestDidPowerUp
+	DidPowerUpRequest    id.DidPowerUpRequest
 
 	// x/memberships messages
 	MsgInviteUser               memberships.MsgInviteUser
@@ -57,6 +59,7 @@ type (
 	// x/commerciomint messages
 	MsgOpenCdp  = commerciomint.MsgOpenCdp
 	MsgCloseCdp = commerciomint.MsgCloseCdp
+	Position    = commerciomint.Position
 )
 
 // Membership types definition
@@ -0,0 +1,143 @@
+package commercio
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// WatchEvent is an item delivered by a DocumentWatcher: exactly one of its fields is set.
+type WatchEvent struct {
+	// Document is a new document received by the watched account.
+	Document *Document
+
+	// Receipt is a new receipt received by the watched account, for a document it sent.
+	Receipt *DocumentReceipt
+
+	// Err is an error happened while polling the LCD, polling goes on after it.
+	Err error
+}
+
+// DocumentWatcher periodically polls the pre-defined LCD for documents and receipts received by the account
+// associated to an SDK, and delivers the ones not delivered yet.
+type DocumentWatcher struct {
+	sdk      *SDK
+	store    CursorStore
+	interval time.Duration
+}
+
+// NewDocumentWatcher returns a DocumentWatcher polling every interval, which uses store to keep track of the
+// documents and receipts already delivered.
+func (sdk *SDK) NewDocumentWatcher(store CursorStore, interval time.Duration) (*DocumentWatcher, error) {
+	if store == nil {
+		return nil, errors.New("missing cursor store")
+	}
+
+	if interval <= 0 {
+		return nil, errors.New("interval must be positive")
+	}
+
+	return &DocumentWatcher{
+		sdk:      sdk,
+		store:    store,
+		interval: interval,
+	}, nil
+}
+
+// Watch starts polling, and returns the channel new documents and receipts are delivered on.
+// Items are marked as delivered in the cursor store once they have been received from the channel.
+// Polling stops and the channel gets closed when ctx is done.
+func (dw *DocumentWatcher) Watch(ctx context.Context) (<-chan WatchEvent, error) {
+	address, err := types.AccAddressFromBech32(dw.sdk.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan WatchEvent)
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(dw.interval)
+		defer ticker.Stop()
+
+		for {
+			if !dw.poll(ctx, address, events) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// poll queries received documents and receipts of address and delivers the new ones on events.
+// It returns false if ctx is done.
+func (dw *DocumentWatcher) poll(ctx context.Context, address types.AccAddress, events chan<- WatchEvent) bool {
+	docs, err := dw.sdk.ReceivedDocuments(address)
+	if err != nil {
+		return dw.deliver(ctx, events, "", WatchEvent{Err: err})
+	}
+
+	for i := range docs {
+		if !dw.deliverNew(ctx, events, "document:"+docs[i].UUID, WatchEvent{Document: &docs[i]}) {
+			return false
+		}
+	}
+
+	receipts, err := dw.sdk.ReceivedReceipts(address)
+	if err != nil {
+		return dw.deliver(ctx, events, "", WatchEvent{Err: err})
+	}
+
+	for i := range receipts {
+		if !dw.deliverNew(ctx, events, "receipt:"+receipts[i].UUID, WatchEvent{Receipt: &receipts[i]}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// deliverNew delivers event if the item identified by key hasn't been delivered yet.
+// It returns false if ctx is done.
+func (dw *DocumentWatcher) deliverNew(ctx context.Context, events chan<- WatchEvent, key string, event WatchEvent) bool {
+	seen, err := dw.store.Seen(key)
+	if err != nil {
+		return dw.deliver(ctx, events, "", WatchEvent{Err: err})
+	}
+
+	if seen {
+		return true
+	}
+
+	return dw.deliver(ctx, events, key, event)
+}
+
+// deliver sends event on events, then marks the item identified by key as delivered if key isn't empty.
+// It returns false if ctx is done.
+func (dw *DocumentWatcher) deliver(ctx context.Context, events chan<- WatchEvent, key string, event WatchEvent) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case events <- event:
+	}
+
+	if key == "" {
+		return true
+	}
+
+	if err := dw.store.MarkSeen(key); err != nil {
+		return dw.deliver(ctx, events, "", WatchEvent{Err: err})
+	}
+
+	return true
+}
@@ -0,0 +1,115 @@
+package commercio
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDK_NewDocumentWatcher(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		store    CursorStore
+		interval time.Duration
+		wantErr  bool
+	}{
+		{
+			"missing store",
+			nil,
+			time.Second,
+			true,
+		},
+		{
+			"zero interval",
+			NewMemoryCursorStore(),
+			0,
+			true,
+		},
+		{
+			"all ok",
+			NewMemoryCursorStore(),
+			time.Second,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := sdk.NewDocumentWatcher(tt.store, tt.interval)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, res)
+		})
+	}
+}
+
+func TestDocumentWatcher_Watch(t *testing.T) {
+	sdk, err := NewSDK("first purse atom language viable marble switch industry pill prevent drive develop prison art hard useless search shoulder promote rapid split wrestle balcony focus", DefaultSDKConfig)
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// the first poll fails, the second returns a document, the following ones a new document as well
+	docsPolls := 0
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/docs/"+sdk.Address+"/received", func(req *http.Request) (*http.Response, error) {
+		docsPolls++
+		switch docsPolls {
+		case 1:
+			return httpmock.NewStringResponse(http.StatusInternalServerError, `{"error":"error!"}`), nil
+		case 2:
+			return httpmock.NewStringResponse(http.StatusOK, `{"height":"42","result":[{"uuid":"first"}]}`), nil
+		default:
+			return httpmock.NewStringResponse(http.StatusOK, `{"height":"42","result":[{"uuid":"first"},{"uuid":"second"}]}`), nil
+		}
+	})
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost:1317/receipts/"+sdk.Address+"/received", func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(http.StatusOK, `{"height":"42","result":[{"uuid":"receipt"}]}`), nil
+	})
+
+	store := NewMemoryCursorStore()
+	require.NoError(t, store.MarkSeen("receipt:receipt"))
+
+	dw, err := sdk.NewDocumentWatcher(store, time.Millisecond)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := dw.Watch(ctx)
+	require.NoError(t, err)
+
+	event := <-events
+	require.Error(t, event.Err)
+
+	event = <-events
+	require.NoError(t, event.Err)
+	require.Equal(t, "first", event.Document.UUID)
+
+	event = <-events
+	require.NoError(t, event.Err)
+	require.Equal(t, "second", event.Document.UUID)
+
+	cancel()
+
+	// the channel gets closed without delivering anything else
+	for event := range events {
+		require.Fail(t, "unexpected event", "%+v", event)
+	}
+
+	seen, err := store.Seen("document:second")
+	require.NoError(t, err)
+	require.True(t, seen)
+}